@@ -1,6 +1,10 @@
 package graph
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
 	"math"
 	"slices"
 )
@@ -12,8 +16,25 @@ type Queue[K comparable] []Node[K]
 // through a graph
 type Path[K comparable] []Node[K]
 
+// error returned by searches that only make sense when every edge
+// has the same weight
+var ErrWeightedGraph = errors.New("graph has edge weights other than 1.0, use Dijkstra")
+
+// error returned by shortest path searches that run into a cycle whose
+// weights add up to less than zero, which makes paths through it
+// cheaper without end
+var ErrNegativeCycle = errors.New("graph has a negative cycle")
+
 // implement a breadth-first search from a start node
 // to a destination node. returns the path, and its length
+// in nodes. a reachable target gives a non-empty path that
+// starts at start and ends at target, so the path to the
+// start itself is just that node, with length 1. an
+// unreachable target gives an empty path and length 0.
+//
+// BFS ignores edge weights and finds the path with the fewest
+// edges. on a weighted graph that's not the cheapest path, use
+// Dijkstra for that, or BFSStrict to catch the mistake
 func (g *graphData[K]) BFS(start, target Node[K]) (Path[K], int) {
 	// if we're already there...
 	if start == target {
@@ -31,7 +52,7 @@ func (g *graphData[K]) BFS(start, target Node[K]) (Path[K], int) {
 	queue[0] = start
 
 	// initialize the path by keeping track of the prior step to each node
-	previous := make(map[Node[K]]Node[K])
+	previous := make(Paths[K])
 
 	// process while queue isn't empty
 	for len(queue) > 0 {
@@ -44,7 +65,7 @@ func (g *graphData[K]) BFS(start, target Node[K]) (Path[K], int) {
 			break
 		}
 		// go through all the possible neighbors of the current node
-		for neighbor := range g.Adjacencies[current] {
+		for neighbor := range g.successorsSeq(current) {
 			// check if we've already been at this neighbor
 			if _, explored := visited[neighbor]; !explored {
 				visited[neighbor] = true
@@ -55,67 +76,329 @@ func (g *graphData[K]) BFS(start, target Node[K]) (Path[K], int) {
 	}
 
 	// check if the target could in fact be reached
-	if _, ok := previous[target]; !ok {
+	path, ok := ReconstructPath(previous, start, target)
+	if !ok {
 		// no, can't get to it, return empty path and zero length
 		return Path[K]{}, 0
 	}
 
-	// build the path from parent relationships
-	path := make(Path[K], 1)
+	// return the path and its length
+	return path, len(path)
+}
+
+// function to check whether the target can be reached from the start
+// in at most maxDepth steps, ignoring edge weights. runs a BFS that
+// stops as soon as it finds the target, and never looks further out
+// than maxDepth steps, so a far away or unreachable target doesn't
+// cost a search of the whole graph. the start reaches itself in zero
+// steps, and a negative maxDepth reaches nothing
+func (g *graphData[K]) ReachableWithin(start, target Node[K], maxDepth int) bool {
+	if maxDepth < 0 || !g.HasNode(start) || !g.HasNode(target) {
+		return false
+	}
+	if start == target {
+		return true
+	}
+
+	visited := map[Node[K]]bool{start: true}
+	// work through the graph one ring of equally distant nodes at a time
+	frontier := Queue[K]{start}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		next := make(Queue[K], 0)
+		for _, current := range frontier {
+			for neighbor := range g.successorsSeq(current) {
+				if neighbor == target {
+					return true
+				}
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+	return false
+}
+
+// find a path from start to target with iterative deepening: a
+// depth-limited DFS is run with a limit of 0, 1, 2, ... steps up to
+// maxDepth, and the first path found is returned along with true. since
+// every shorter limit came up empty, the path has the fewest possible
+// edges, like the one from BFS. memory only grows with the depth of the
+// search rather than with the size of the BFS frontier, at the price
+// of exploring the nodes near the start again for every limit. returns
+// an empty path and false if there's no path within maxDepth steps
+func (g *graphData[K]) IterativeDeepeningSearch(start, target Node[K], maxDepth int) (Path[K], bool) {
+	if maxDepth < 0 || !g.HasNode(start) || !g.HasNode(target) {
+		return Path[K]{}, false
+	}
+
+	// a frame on the explicit stack, with the neighbors left to try
+	type frame struct {
+		node      Node[K]
+		neighbors []Node[K]
+	}
+
+	for limit := 0; limit <= maxDepth; limit++ {
+		// the path so far, and the nodes on it so it doesn't loop
+		path := Path[K]{start}
+		onPath := map[Node[K]]bool{start: true}
+		stack := []frame{{start, g.Successors(start)}}
+		// whether any branch was cut short by the limit. if none was,
+		// raising the limit can't find anything new
+		cutoff := false
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.node == target {
+				return path, true
+			}
+			if len(path)-1 == limit || len(top.neighbors) == 0 {
+				if len(path)-1 == limit && len(top.neighbors) > 0 {
+					cutoff = true
+				}
+				// backtrack
+				delete(onPath, top.node)
+				path = path[:len(path)-1]
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			// step to the next neighbor that isn't already on the path
+			next := top.neighbors[0]
+			top.neighbors = top.neighbors[1:]
+			if onPath[next] {
+				continue
+			}
+			onPath[next] = true
+			path = append(path, next)
+			stack = append(stack, frame{next, g.Successors(next)})
+		}
+
+		if !cutoff {
+			break
+		}
+	}
+	return Path[K]{}, false
+}
+
+// like BFS, but returns ErrWeightedGraph instead of searching if any
+// edge has a weight other than 1.0, see IsUnweighted. checking costs a
+// pass over all edges
+func (g *graphData[K]) BFSStrict(start, target Node[K]) (Path[K], int, error) {
+	if !g.IsUnweighted() {
+		return Path[K]{}, 0, ErrWeightedGraph
+	}
+	path, l := g.BFS(start, target)
+	return path, l, nil
+}
+
+type Distances[K comparable] map[Node[K]]float64
+type Paths[K comparable] map[Node[K]]Node[K]
+
+// function to build the path from start to target by walking back
+// through the previous map, as returned by DFS or Dijkstra. returns
+// the path and true, or an empty path and false if the target wasn't
+// reached. the path from a node to itself is just that node. a broken
+// previous map, where the chain back from the target runs into a node
+// without a parent or loops without reaching start, also gives an
+// empty path and false rather than walking forever
+func ReconstructPath[K comparable](previous Paths[K], start, target Node[K]) (Path[K], bool) {
+	if start == target {
+		return Path[K]{target}, true
+	}
+	if _, ok := previous[target]; !ok {
+		return Path[K]{}, false
+	}
+
 	// walk back from the target
-	path[0] = target
+	path := Path[K]{target}
 	current := target
 	for current != start {
-		step := previous[current]
-		current = previous[current]
-		path = append(path, step)
+		parent, ok := previous[current]
+		// a valid chain visits every node at most once, so a path
+		// longer than the map means we're going around in circles
+		if !ok || len(path) > len(previous) {
+			return Path[K]{}, false
+		}
+		current = parent
+		path = append(path, current)
 	}
 	// and reverse it
 	slices.Reverse(path)
+	return path, true
+}
 
-	// return the path and its length
-	return path, len(path)
+// walk the graph depth-first from a start node. returns the depth of
+// each node in the DFS tree, the tree itself as previous nodes, and the
+// order in which nodes were discovered. uses an explicit stack rather
+// than recursion so that deep graphs don't overflow
+func (g *graphData[K]) dfs(start Node[K]) (Distances[K], Paths[K], []Node[K]) {
+	distances := make(Distances[K])
+	previous := make(Paths[K])
+	order := make([]Node[K], 0)
+	// nodes that can't be reached stay at infinity
+	for node := range g.NodesSeq() {
+		distances[node] = math.Inf(1)
+	}
+
+	// each stack entry remembers the node it was pushed from
+	type entry struct {
+		node, parent Node[K]
+	}
+	stack := []entry{{node: start, parent: start}}
+	visited := make(map[Node[K]]bool)
+
+	for len(stack) > 0 {
+		// pop the top of the stack
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		// a node can be pushed several times, only the first pop counts
+		if visited[current.node] {
+			continue
+		}
+		visited[current.node] = true
+
+		// record where we came from and how deep we are
+		previous[current.node] = current.parent
+		if current.node == start {
+			distances[start] = 0.0
+		} else {
+			distances[current.node] = distances[current.parent] + 1.0
+		}
+		order = append(order, current.node)
+
+		// push all the neighbors we haven't been to yet
+		for neighbor := range g.successorsSeq(current.node) {
+			if !visited[neighbor] {
+				stack = append(stack, entry{node: neighbor, parent: current.node})
+			}
+		}
+	}
+
+	return distances, previous, order
 }
 
-type Distances[K comparable] map[Node[K]]float64
-type Paths[K comparable] map[Node[K]]Node[K]
+// implement a depth-first search from a start node. returns the depth
+// of each node in the DFS tree and the previous node for each node on
+// its way from the start. unreachable nodes are at infinite depth
+func (g *graphData[K]) DFS(start Node[K]) (Distances[K], Paths[K]) {
+	distances, previous, _ := g.dfs(start)
+	return distances, previous
+}
+
+// function to return the nodes reachable from a start node in the
+// order a depth-first search discovers them
+func (g *graphData[K]) DFSOrder(start Node[K]) []Node[K] {
+	_, _, order := g.dfs(start)
+	return order
+}
+
+// function to walk the graph breadth-first from a start node, yielding
+// nodes in the order they're visited. the walk stops as soon as the
+// consumer stops asking for more nodes
+func (g *graphData[K]) BFSIter(start Node[K]) iter.Seq[Node[K]] {
+	return func(yield func(Node[K]) bool) {
+		queue := Queue[K]{start}
+		visited := map[Node[K]]bool{start: true}
+
+		for len(queue) > 0 {
+			// pop the front of the queue and hand it out
+			current := queue[0]
+			queue = queue[1:]
+			if !yield(current) {
+				return
+			}
+			// queue up the neighbors we haven't seen yet
+			for neighbor := range g.successorsSeq(current) {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+}
+
+// function to walk the graph depth-first from a start node, yielding
+// nodes in the order they're discovered. the walk stops as soon as the
+// consumer stops asking for more nodes
+func (g *graphData[K]) DFSIter(start Node[K]) iter.Seq[Node[K]] {
+	return func(yield func(Node[K]) bool) {
+		stack := []Node[K]{start}
+		visited := make(map[Node[K]]bool)
+
+		for len(stack) > 0 {
+			// pop the top of the stack
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			// a node can be pushed several times, only the first pop counts
+			if visited[current] {
+				continue
+			}
+			visited[current] = true
+			if !yield(current) {
+				return
+			}
+
+			// push all the neighbors we haven't been to yet
+			for neighbor := range g.successorsSeq(current) {
+				if !visited[neighbor] {
+					stack = append(stack, neighbor)
+				}
+			}
+		}
+	}
+}
 
 // calculate the shortest path from a given start to
 // all other nodes. return the distances and previous
 // nodes for each node in the graph
 func (g *graphData[K]) Dijkstra(start Node[K]) (Distances[K], Paths[K]) {
-	// initialize the queue and data structures to hold
-	// the distances and prior nodes on the paths
-	queue := make(Queue[K], 0)
+	distances, previous, _, _ := g.dijkstra([]Node[K]{start}, nil, nil)
+	return distances, previous
+}
+
+// helper to run Dijkstra from any number of start nodes at once. if
+// stop isn't nil, the search ends as soon as a node matching it is
+// settled, and that node is returned along with true. otherwise the
+// whole graph is searched. if tieBreak isn't nil, nodes at the same
+// distance are settled in the order it gives their IDs
+func (g *graphData[K]) dijkstra(starts []Node[K], stop func(Node[K]) bool, tieBreak func(a, b K) int) (Distances[K], Paths[K], Node[K], bool) {
+	// initialize the data structures to hold the distances
+	// and prior nodes on the paths
 	distances := make(Distances[K])
 	previous := make(Paths[K])
-	// for each node, set the distance to infinity and add
-	// it to the queue
-	for node := range g.Adjacencies {
+	// for each node, set the distance to infinity
+	for node := range g.NodesSeq() {
 		distances[node] = math.Inf(1)
-		queue = append(queue, node)
 	}
-	// distance to the starting node is 0.0
-	distances[start] = 0.0
-	// can get to self
-	previous[start] = start
+	// seed the queue with the starting nodes. other nodes are
+	// added to it as they are reached
+	queue := newDistanceQueue[K]()
+	queue.tieBreak = tieBreak
+	for _, start := range starts {
+		// distance to the starting node is 0.0
+		distances[start] = 0.0
+		// can get to self
+		previous[start] = start
+		queue.update(start, 0.0)
+	}
 
 	// process queue while it isn't empty
-	for len(queue) > 0 {
-		// find the node with the smallest distance still in the queue
-		min_distance := math.Inf(1)
-		min_index := 0
-		for i := range queue {
-			if distances[queue[i]] < min_distance {
-				min_index = i
-			}
+	for queue.Len() > 0 {
+		// fetch the node with the smallest distance still in the queue
+		current, _ := queue.pop()
+		// nothing can be closer than this, so if it's a goal we're done
+		if stop != nil && stop(current) {
+			return distances, previous, current, true
 		}
-		// fetch it, and remove it from the queue
-		current := queue[min_index]
-		queue = slices.Delete(queue, min_index, min_index+1)
 
 		// go through all the possible neighbors of the current node
-		for neighbor, weight := range g.Adjacencies[current] {
+		for neighbor, weight := range g.successorsSeq(current) {
 			// calculate the distance from this node to the neighbor
 			// by adding the weight of the edge
 			alternative := distances[current] + weight
@@ -125,37 +408,301 @@ func (g *graphData[K]) Dijkstra(start Node[K]) (Distances[K], Paths[K]) {
 				// on the path to it
 				distances[neighbor] = alternative
 				previous[neighbor] = current
+				// and queue it up, or decrease its key if already queued
+				queue.update(neighbor, alternative)
 			}
 		}
 	}
 
+	return distances, previous, Node[K]{}, false
+}
+
+// calculate the shortest path from a given start to all other nodes
+// with the Bellman-Ford algorithm. unlike Dijkstra, edge weights may be
+// negative, at the cost of O(V * E) time. every round relaxes all the
+// edges, and after V-1 rounds all distances are final, unless a cycle
+// that can be reached from start adds up to less than zero. that gives
+// ErrNegativeCycle. an undirected edge goes both ways, so a single
+// negative one is such a cycle. returns the distances and previous
+// nodes like Dijkstra does
+func (g *graphData[K]) BellmanFord(start Node[K]) (Distances[K], Paths[K], error) {
+	if !g.HasNode(start) {
+		return nil, nil, fmt.Errorf("start node %v not in graph", start.ID)
+	}
+	distances := make(Distances[K])
+	previous := make(Paths[K])
+	for node := range g.NodesSeq() {
+		distances[node] = math.Inf(1)
+	}
+	distances[start] = 0.0
+	previous[start] = start
+
+	// helper to relax every edge once. returns whether any distance changed
+	relax := func() bool {
+		changed := false
+		for u, table := range g.Adjacencies {
+			from := Node[K]{ID: u}
+			if math.IsInf(distances[from], 1) {
+				continue
+			}
+			for v, w := range table {
+				to := Node[K]{ID: v}
+				if alternative := distances[from] + w; alternative < distances[to] {
+					distances[to] = alternative
+					previous[to] = from
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+
+	for range len(g.Adjacencies) - 1 {
+		if !relax() {
+			return distances, previous, nil
+		}
+	}
+	// anything that still gets cheaper is on or behind a negative cycle
+	if relax() {
+		return nil, nil, ErrNegativeCycle
+	}
+	return distances, previous, nil
+}
+
+// like Dijkstra, but nodes at the same distance are settled in order of
+// their IDs, as given by less or the default order if it's nil, see
+// SortedNodes. the first node settled at a distance is the one paths
+// go through, so among several shortest paths the same one is picked
+// every run, where Dijkstra may pick a different one each time
+func (g *graphData[K]) DijkstraStable(start Node[K], less func(a, b K) bool) (Distances[K], Paths[K]) {
+	distances, previous, _, _ := g.dijkstra([]Node[K]{start}, nil, compareBy(less))
 	return distances, previous
 }
 
+// like DijkstraTo, but picks the same path every run, see DijkstraStable
+func (g *graphData[K]) DijkstraToStable(start, target Node[K], less func(a, b K) bool) (Path[K], int, float64) {
+	// if we're already there...
+	if start == target {
+		return Path[K]{target}, 1, 0.0
+	}
+
+	distances, previous, _, _ := g.dijkstra([]Node[K]{start}, func(n Node[K]) bool { return n == target }, compareBy(less))
+	path, ok := ReconstructPath(previous, start, target)
+	if !ok {
+		return Path[K]{}, 0, math.Inf(1)
+	}
+	return path, len(path), distances[target]
+}
+
 // calculate the shortest path from a given node to a given node
-// returns the path, the length of the path, and the distance cost
+// returns the path, the length of the path, and the distance cost.
+// follows the same contract as BFS: a reachable target gives a
+// non-empty path, an unreachable target gives an empty path and
+// length 0, and in that case the cost is infinite
 func (g *graphData[K]) DijkstraTo(start, target Node[K]) (Path[K], int, float64) {
+	// if we're already there...
+	if start == target {
+		return Path[K]{target}, 1, 0.0
+	}
+
+	// run Dijkstra until the target is settled, nothing further away
+	// matters for the one path
+	distances, previous, _, ok := g.dijkstra([]Node[K]{start}, func(n Node[K]) bool { return n == target }, nil)
+	if !ok {
+		return Path[K]{}, 0, math.Inf(1)
+	}
+	path, _ := ReconstructPath(previous, start, target)
+	return path, len(path), distances[target]
+}
+
+// run Dijkstra once from the given node and return the distances, along
+// with a function to reconstruct the path to any target on demand. the
+// function follows the same contract as DijkstraTo, which makes this the
+// cheaper choice when looking up paths to several targets
+func (g *graphData[K]) DijkstraPathsFrom(start Node[K]) (Distances[K], func(target Node[K]) (Path[K], int, float64)) {
 	// calculate the graph distances and paths
 	distances, previous := g.Dijkstra(start)
 
-	// check that the target can be reached from the given start
-	if _, ok := previous[target]; !ok {
-		// it cannot
+	pathTo := func(target Node[K]) (Path[K], int, float64) {
+		// check that the target can be reached from the given start
+		path, ok := ReconstructPath(previous, start, target)
+		if !ok {
+			// it cannot
+			return Path[K]{}, 0, math.Inf(1)
+		}
+		return path, len(path), distances[target]
+	}
+	return distances, pathTo
+}
+
+// calculate the shortest distances from the nearest of several start
+// nodes to all other nodes, as if they were a single start. previous
+// leads every reachable node back to its nearest start, and each start
+// is its own previous node. unreachable nodes are at infinite distance
+func (g *graphData[K]) MultiSourceDijkstra(starts []Node[K]) (Distances[K], Paths[K]) {
+	distances, previous, _, _ := g.dijkstra(starts, nil, nil)
+	return distances, previous
+}
+
+// like MultiSourceDijkstra, but ignores edge weights and counts the
+// number of steps from the nearest start instead. on a weighted graph
+// those aren't the distances MultiSourceDijkstra would return
+func (g *graphData[K]) MultiSourceBFS(starts []Node[K]) (Distances[K], Paths[K]) {
+	distances := make(Distances[K])
+	previous := make(Paths[K])
+	// nodes that can't be reached stay at infinity
+	for node := range g.NodesSeq() {
+		distances[node] = math.Inf(1)
+	}
+
+	// seed the queue with all the starts at once
+	queue := make(Queue[K], 0, len(starts))
+	for _, start := range starts {
+		if _, seen := previous[start]; seen {
+			continue
+		}
+		distances[start] = 0.0
+		previous[start] = start
+		queue = append(queue, start)
+	}
+
+	// every node is first reached from the closest start
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for neighbor := range g.successorsSeq(current) {
+			if _, seen := previous[neighbor]; !seen {
+				distances[neighbor] = distances[current] + 1.0
+				previous[neighbor] = current
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return distances, previous
+}
+
+// calculate the shortest path from a given node to the closest node
+// for which isGoal returns true. follows the same contract as
+// DijkstraTo: returns the path, its length, and its cost, or an empty
+// path, length 0, and infinite cost if no goal can be reached. if the
+// start is a goal itself, the path is just the start
+func (g *graphData[K]) DijkstraToFunc(start Node[K], isGoal func(Node[K]) bool) (Path[K], int, float64) {
+	// if we're already there...
+	if isGoal(start) {
+		return Path[K]{start}, 1, 0.0
+	}
+
+	distances, previous, goal, ok := g.dijkstra([]Node[K]{start}, isGoal, nil)
+	if !ok {
 		return Path[K]{}, 0, math.Inf(1)
 	}
+	path, _ := ReconstructPath(previous, start, goal)
+	return path, len(path), distances[goal]
+}
 
-	// build the path from parent relationships
-	path := make(Path[K], 1)
-	// walk back from the target
-	path[0] = target
-	current := target
-	for current != start {
-		step := previous[current]
-		current = previous[current]
-		path = append(path, step)
+// calculate the shortest path from a given node to a given node by
+// running Dijkstra from both ends at once, forward from the start and
+// backward from the target along the predecessors. whichever search has
+// the closer frontier takes the next step, and every time they touch
+// the best path through the meeting point is remembered. once the two
+// frontiers together are at least as far as that path, nothing shorter
+// can turn up. follows the same contract as DijkstraTo
+func (g *graphData[K]) BidirectionalDijkstra(start, target Node[K]) (Path[K], int, float64) {
+	// if we're already there...
+	if start == target {
+		return Path[K]{target}, 1, 0.0
+	}
+	if !g.HasNode(start) || !g.HasNode(target) {
+		return Path[K]{}, 0, math.Inf(1)
 	}
-	// and reverse it
-	slices.Reverse(path)
 
-	return path, len(path), distances[target]
+	// the state of one direction of the search
+	type search struct {
+		distances Distances[K]
+		previous  Paths[K]
+		queue     *distanceQueue[K]
+		edges     func(Node[K]) iter.Seq2[Node[K], float64]
+	}
+	newSearch := func(from Node[K], edges func(Node[K]) iter.Seq2[Node[K], float64]) *search {
+		s := &search{
+			distances: Distances[K]{from: 0.0},
+			previous:  Paths[K]{from: from},
+			queue:     newDistanceQueue[K](),
+			edges:     edges,
+		}
+		s.queue.push(from, 0.0)
+		return s
+	}
+	forward := newSearch(start, g.successorsSeq)
+	backward := newSearch(target, g.predecessorsSeq)
+
+	best := math.Inf(1)
+	var meeting Node[K]
+	for forward.queue.Len() > 0 && backward.queue.Len() > 0 {
+		// stop once no path through the frontiers can beat the best one
+		if forward.queue.peek()+backward.queue.peek() >= best {
+			break
+		}
+		// step the search with the closer frontier
+		current, other := forward, backward
+		if backward.queue.peek() < forward.queue.peek() {
+			current, other = backward, forward
+		}
+
+		node, distance := current.queue.pop()
+		for neighbor, weight := range current.edges(node) {
+			alternative := distance + weight
+			if d, ok := current.distances[neighbor]; ok && alternative >= d {
+				continue
+			}
+			current.distances[neighbor] = alternative
+			current.previous[neighbor] = node
+			current.queue.update(neighbor, alternative)
+			// has the other search been here? then there's a path
+			if d, ok := other.distances[neighbor]; ok && alternative+d < best {
+				best = alternative + d
+				meeting = neighbor
+			}
+		}
+	}
+
+	if math.IsInf(best, 1) {
+		return Path[K]{}, 0, best
+	}
+	// join the halves at the meeting point. the backward half leads from
+	// the meeting point to the target, so it only needs reversing
+	path, _ := ReconstructPath(forward.previous, start, meeting)
+	rest, _ := ReconstructPath(backward.previous, target, meeting)
+	slices.Reverse(rest)
+	path = append(path, rest[1:]...)
+	return path, len(path), best
+}
+
+// calculate a minimum spanning tree using Kruskal's algorithm. returns
+// a new graph holding all nodes and only the tree edges, as well as the
+// total weight of the tree. if the graph is disconnected, the result is
+// a minimum spanning forest with one tree per component
+func (g *UndirectedGraph[K]) MinimumSpanningTree() (*UndirectedGraph[K], float64) {
+	tree := NewUndirectedGraph[K]()
+	// every node is part of the spanning forest, even isolated ones
+	tree.AddNodesFrom(g.Nodes())
+
+	// consider edges from cheapest to most expensive
+	edges := g.Edges()
+	slices.SortFunc(edges, func(a, b Edge[K]) int {
+		return cmp.Compare(a.weight, b.weight)
+	})
+
+	// add each edge unless its endpoints are already connected
+	uf := newUnionFind[K]()
+	total := 0.0
+	for _, e := range edges {
+		if uf.union(e.u, e.v) {
+			tree.AddEdge(e.u, e.v, e.weight)
+			total += e.weight
+		}
+	}
+
+	return tree, total
 }
@@ -1,7 +1,13 @@
 package graph
 
 import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"testing"
 )
 
@@ -49,6 +55,271 @@ func TestBFS(t *testing.T) {
 	})
 }
 
+func TestBFSStrict(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Unweighted graph", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		path, l, err := g.BFSStrict(u, w)
+		if err != nil || l != 3 || !slices.Equal(path, Path[int]{u, v, w}) {
+			t.Errorf("Expected path %v, got %v and %v", Path[int]{u, v, w}, path, err)
+		}
+	})
+
+	t.Run("Weighted graph", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.SetEdgeWeight(v, w, 2.0)
+		path, l, err := g.BFSStrict(u, w)
+		if !errors.Is(err, ErrWeightedGraph) {
+			t.Errorf("Expected ErrWeightedGraph, got %v", err)
+		}
+		if path == nil || len(path) != 0 || l != 0 {
+			t.Errorf("Expected empty path and length 0, got %v and %d", path, l)
+		}
+	})
+}
+
+func TestReachableWithin(t *testing.T) {
+	// a path of 5 nodes, 4 steps from end to end, and an isolated node
+	g := PathGraph([]int{1, 2, 3, 4, 5})
+	g.AddNode(Node[int]{6})
+	start := Node[int]{1}
+
+	cases := []struct {
+		target   int
+		maxDepth int
+		expected bool
+	}{
+		{1, 0, true},
+		{2, 0, false},
+		{2, 1, true},
+		{5, 3, false},
+		{5, 4, true},
+		{5, 100, true},
+		{6, 100, false},
+		{7, 100, false},
+		{1, -1, false},
+	}
+	for _, c := range cases {
+		if got := g.ReachableWithin(start, Node[int]{c.target}, c.maxDepth); got != c.expected {
+			t.Errorf("Expected %t for %d within %d steps, got %t", c.expected, c.target, c.maxDepth, got)
+		}
+	}
+
+	t.Run("Directed edges only go one way", func(t *testing.T) {
+		d := NewDirectedGraph[int]()
+		d.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		if !d.ReachableWithin(Node[int]{1}, Node[int]{2}, 1) || d.ReachableWithin(Node[int]{2}, Node[int]{1}, 5) {
+			t.Errorf("Expected 2 reachable from 1 but not the other way")
+		}
+	})
+}
+
+func TestIterativeDeepeningSearch(t *testing.T) {
+	t.Run("Matches BFS on a grid", func(t *testing.T) {
+		grid := []string{
+			".....#....",
+			".###.#.##.",
+			"...#...#..",
+			"##.#####.#",
+			"..........",
+		}
+		g, _ := BuildGridGraph(grid, CardinalDirections, '.')
+		start := Node[Coordinate]{Coordinate{0, 0}}
+		for _, target := range []Coordinate{{0, 0}, {9, 0}, {0, 4}, {6, 2}, {9, 4}} {
+			_, expected := g.BFS(start, Node[Coordinate]{target})
+			path, ok := g.IterativeDeepeningSearch(start, Node[Coordinate]{target}, 100)
+			if !ok || len(path) != expected {
+				t.Errorf("Expected path of %d nodes to %v, got %v", expected, target, path)
+			}
+			if !path.IsValidPath(g) || path[0] != start || path[len(path)-1].ID != target {
+				t.Errorf("Expected a valid path from %v to %v, got %v", start, target, path)
+			}
+		}
+	})
+
+	t.Run("Depth limit", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4, 5, 6})
+		// the shorter way around is 3 steps
+		if path, ok := g.IterativeDeepeningSearch(Node[int]{1}, Node[int]{4}, 2); ok || path == nil || len(path) != 0 {
+			t.Errorf("Expected no path within 2 steps, got %v", path)
+		}
+		if path, ok := g.IterativeDeepeningSearch(Node[int]{1}, Node[int]{4}, 3); !ok || len(path) != 4 {
+			t.Errorf("Expected path of 4 nodes, got %v", path)
+		}
+	})
+
+	t.Run("Unreachable target", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.AddNode(Node[int]{4})
+		if path, ok := g.IterativeDeepeningSearch(Node[int]{1}, Node[int]{4}, 1000000); ok || len(path) != 0 {
+			t.Errorf("Expected no path, got %v", path)
+		}
+	})
+}
+
+func TestDFS(t *testing.T) {
+	// create a directed graph
+	g := NewDirectedGraph[int]()
+	u, v, w, x, y, z := getNodes()
+
+	// a branch from u to v and w, which continues to x and y
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(u, w, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddEdge(x, y, 1.0)
+
+	// add an unreachable node
+	g.AddNode(z)
+
+	distances, previous := g.DFS(u)
+
+	t.Run("DFS depths", func(t *testing.T) {
+		if distances[u] != 0.0 || distances[y] != 3.0 {
+			t.Errorf("DFS expected depth 0 for u and 3 for y, got %f and %f", distances[u], distances[y])
+		}
+		if distances[z] != math.Inf(1) {
+			t.Errorf("DFS expected infinite depth for z, got %f", distances[z])
+		}
+	})
+
+	t.Run("DFS tree", func(t *testing.T) {
+		// walk back from y to u
+		path := Path[int]{y}
+		for current := y; current != u; {
+			current = previous[current]
+			path = append(path, current)
+		}
+		slices.Reverse(path)
+		if !slices.Equal(path, Path[int]{u, w, x, y}) {
+			t.Errorf("DFS expected tree path from u to y over w and x, got %v", path)
+		}
+		if _, ok := previous[z]; ok {
+			t.Errorf("DFS expected no previous node for z")
+		}
+	})
+
+	t.Run("DFS order", func(t *testing.T) {
+		order := g.DFSOrder(u)
+		if len(order) != 5 || order[0] != u {
+			t.Fatalf("DFS expected 5 nodes starting with u, got %v", order)
+		}
+		// whichever branch is taken first is finished before the other
+		wi, vi := slices.Index(order, w), slices.Index(order, v)
+		xi, yi := slices.Index(order, x), slices.Index(order, y)
+		if !(xi == wi+1 && yi == xi+1) || (vi > wi && vi < yi) {
+			t.Errorf("DFS expected branch w, x, y to be explored contiguously, got %v", order)
+		}
+	})
+}
+
+func TestTraversalIterators(t *testing.T) {
+	// create an undirected graph
+	g := NewUndirectedGraph[int]()
+	u, v, w, x, y, z := getNodes()
+
+	// a line from u to y
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddEdge(x, y, 1.0)
+
+	// add an unreachable node
+	g.AddNode(z)
+
+	t.Run("BFS iterator visits reachable nodes", func(t *testing.T) {
+		order := slices.Collect(g.BFSIter(w))
+		if len(order) != 5 || order[0] != w || slices.Contains(order, z) {
+			t.Errorf("BFS iterator expected 5 nodes starting at w without z, got %v", order)
+		}
+		// nodes close to w come before nodes further away
+		if slices.Index(order, u) < slices.Index(order, x) {
+			t.Errorf("BFS iterator expected x before u, got %v", order)
+		}
+	})
+
+	t.Run("DFS iterator visits reachable nodes", func(t *testing.T) {
+		order := slices.Collect(g.DFSIter(u))
+		if !slices.Equal(order, []Node[int]{u, v, w, x, y}) {
+			t.Errorf("DFS iterator expected nodes along the line, got %v", order)
+		}
+	})
+
+	t.Run("Iterators stop early", func(t *testing.T) {
+		for _, seq := range []func(Node[int]) iter.Seq[Node[int]]{g.BFSIter, g.DFSIter} {
+			count := 0
+			for n := range seq(u) {
+				count++
+				if n == w {
+					break
+				}
+			}
+			if count != 3 {
+				t.Errorf("Iterator expected to stop after 3 nodes, got %d", count)
+			}
+		}
+	})
+}
+
+func TestPathToSelf(t *testing.T) {
+	// create a directed graph
+	g := NewDirectedGraph[int]()
+	u, v, _, _, _, z := getNodes()
+	g.AddEdge(u, v, 1.0)
+
+	// the trivial path is the same whichever search finds it, and
+	// whether or not the node is part of the graph
+	for _, n := range []Node[int]{u, z} {
+		t.Run(fmt.Sprintf("Path from %v to itself", n), func(t *testing.T) {
+			bfsPath, bfsLength := g.BFS(n, n)
+			dPath, dLength, cost := g.DijkstraTo(n, n)
+			if !slices.Equal(bfsPath, Path[int]{n}) || bfsLength != 1 {
+				t.Errorf("BFS expected path of just %v with length 1, got %v and %d", n, bfsPath, bfsLength)
+			}
+			if !slices.Equal(dPath, bfsPath) || dLength != bfsLength || cost != 0.0 {
+				t.Errorf("Dijkstra expected path of just %v with length 1 and cost 0.0, got %v, %d and %f", n, dPath, dLength, cost)
+			}
+		})
+	}
+}
+
+func TestUnreachableContract(t *testing.T) {
+	// create a directed graph
+	g := NewDirectedGraph[int]()
+	u, v, w, x, _, z := getNodes()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 1.0)
+	g.AddNode(x)
+
+	// pairs of start and target that can't be connected: against the
+	// edge direction, to an isolated node, and to and from a node
+	// that isn't part of the graph at all
+	unreachable := [][2]Node[int]{{w, u}, {u, x}, {u, z}, {z, u}}
+	for _, pair := range unreachable {
+		t.Run(fmt.Sprintf("Unreachable from %v to %v", pair[0], pair[1]), func(t *testing.T) {
+			path, l := g.BFS(pair[0], pair[1])
+			if path == nil || len(path) != 0 || l != 0 {
+				t.Errorf("BFS expected empty path and length 0, got %v and %d", path, l)
+			}
+			path, l, cost := g.DijkstraTo(pair[0], pair[1])
+			if path == nil || len(path) != 0 || l != 0 || !math.IsInf(cost, 1) {
+				t.Errorf("Dijkstra expected empty path, length 0, and infinite cost, got %v, %d, and %f", path, l, cost)
+			}
+		})
+	}
+
+	t.Run("Reachable target", func(t *testing.T) {
+		path, l := g.BFS(u, w)
+		if l != len(path) || l == 0 || path[0] != u || path[l-1] != w {
+			t.Errorf("BFS expected non-empty path from u to w, got %v and %d", path, l)
+		}
+		path, l, cost := g.DijkstraTo(u, w)
+		if l != len(path) || l == 0 || path[0] != u || path[l-1] != w || cost != 2.0 {
+			t.Errorf("Dijkstra expected non-empty path from u to w with cost 2.0, got %v, %d, and %f", path, l, cost)
+		}
+	})
+}
+
 func TestDijkstra(t *testing.T) {
 	// create an undirected graph
 	g := NewUndirectedGraph[int]()
@@ -127,3 +398,510 @@ func TestDijkstra(t *testing.T) {
 	})
 
 }
+
+func TestDijkstraPicksMinimumDistance(t *testing.T) {
+	// regression test for the min-distance scan never updating its
+	// baseline, which made it settle whichever reachable node came last
+	// in map order. s has an expensive direct edge to each of x1 to x10,
+	// but the cheap way to each of them runs down the chain from x1, so
+	// every node has to be settled in chain order. the old scan only
+	// gets that right if map order happens to line up, about 1 in 9!,
+	// and the graph is built several times to shuffle that order
+	s := Node[int]{0}
+	chain := make([]Node[int], 10)
+	for i := range chain {
+		chain[i] = Node[int]{i + 1}
+	}
+	for range 10 {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(s, chain[0], 1.0)
+		for i, x := range chain {
+			if i > 0 {
+				g.AddEdge(s, x, 100.0)
+				g.AddEdge(chain[i-1], x, 1.0)
+			}
+		}
+
+		distances, _ := g.Dijkstra(s)
+		for i, x := range chain {
+			if distances[x] != float64(i+1) {
+				t.Fatalf("Dijkstra expected distance %d to %v, got %f", i+1, x.ID, distances[x])
+			}
+		}
+		path, l, cost := g.DijkstraTo(s, chain[9])
+		if l != 11 || cost != 10.0 {
+			t.Fatalf("Dijkstra expected path of 11 nodes with cost 10.0, got %v with %d nodes and cost %f", path, l, cost)
+		}
+	}
+}
+
+func TestDijkstraStable(t *testing.T) {
+	// a symmetric diamond, with two shortest ways from a to d, and a
+	// second diamond behind it so the choice compounds
+	build := func() *UndirectedGraph[string] {
+		g := NewUndirectedGraph[string]()
+		for _, e := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}, {"d", "e"}, {"d", "f"}, {"e", "g"}, {"f", "g"}} {
+			g.AddEdge(Node[string]{e[0]}, Node[string]{e[1]}, 1.0)
+		}
+		return g
+	}
+	a, g := Node[string]{"a"}, Node[string]{"g"}
+
+	t.Run("Smallest IDs by default", func(t *testing.T) {
+		expected := Path[string]{{"a"}, {"b"}, {"d"}, {"e"}, {"g"}}
+		// map order changes from graph to graph, the path mustn't
+		for range 50 {
+			path, l, cost := build().DijkstraToStable(a, g, nil)
+			if !slices.Equal(path, expected) || l != 5 || cost != 4.0 {
+				t.Fatalf("Expected %v with cost 4.0, got %v and %f", expected, path, cost)
+			}
+		}
+	})
+
+	t.Run("Custom order", func(t *testing.T) {
+		greater := func(x, y string) bool { return x > y }
+		expected := Path[string]{{"a"}, {"c"}, {"d"}, {"f"}, {"g"}}
+		for range 50 {
+			path, _, _ := build().DijkstraToStable(a, g, greater)
+			if !slices.Equal(path, expected) {
+				t.Fatalf("Expected %v, got %v", expected, path)
+			}
+		}
+	})
+
+	t.Run("Same tree for all targets", func(t *testing.T) {
+		for range 50 {
+			distances, previous := build().DijkstraStable(a, nil)
+			if previous[Node[string]{"d"}] != (Node[string]{"b"}) || previous[g] != (Node[string]{"e"}) || distances[g] != 4.0 {
+				t.Fatalf("Expected d via b and g via e, got %v", previous)
+			}
+		}
+	})
+
+	t.Run("Unreachable target", func(t *testing.T) {
+		h := build()
+		h.AddNode(Node[string]{"z"})
+		path, l, cost := h.DijkstraToStable(a, Node[string]{"z"}, nil)
+		if path == nil || len(path) != 0 || l != 0 || !math.IsInf(cost, 1) {
+			t.Errorf("Expected empty path, length 0, and infinite cost, got %v, %d, and %f", path, l, cost)
+		}
+	})
+}
+
+func TestDijkstraPathsFrom(t *testing.T) {
+	g := NewDirectedGraph[string]()
+	s := Node[string]{"s"}
+	a := Node[string]{"a"}
+	b := Node[string]{"b"}
+	c := Node[string]{"c"}
+	g.AddEdge(s, a, 1.0)
+	g.AddEdge(a, b, 2.0)
+	g.AddEdge(s, b, 5.0)
+	g.AddNode(c)
+
+	distances, pathTo := g.DijkstraPathsFrom(s)
+	if distances[b] != 3.0 {
+		t.Errorf("Expected distance 3.0 to b, got %f", distances[b])
+	}
+
+	// several targets from the one run, matching DijkstraTo
+	for _, target := range []Node[string]{s, a, b, c} {
+		path, l, cost := pathTo(target)
+		expectedPath, expectedL, expectedCost := g.DijkstraTo(s, target)
+		if !slices.Equal(path, expectedPath) || l != expectedL || cost != expectedCost {
+			t.Errorf("Expected %v, %d, %f for %v, got %v, %d, %f", expectedPath, expectedL, expectedCost, target, path, l, cost)
+		}
+	}
+}
+
+// reference implementation of Dijkstra using a linear scan of the queue
+// to find the next node. kept around to benchmark the heap-based version
+func dijkstraLinearScan[K comparable](g *graphData[K], start Node[K]) (Distances[K], Paths[K]) {
+	queue := make(Queue[K], 0)
+	distances := make(Distances[K])
+	previous := make(Paths[K])
+	for node := range g.NodesSeq() {
+		distances[node] = math.Inf(1)
+		queue = append(queue, node)
+	}
+	distances[start] = 0.0
+	previous[start] = start
+
+	for len(queue) > 0 {
+		min_distance := math.Inf(1)
+		min_index := 0
+		for i := range queue {
+			if distances[queue[i]] < min_distance {
+				min_distance = distances[queue[i]]
+				min_index = i
+			}
+		}
+		current := queue[min_index]
+		queue = slices.Delete(queue, min_index, min_index+1)
+
+		for neighbor, weight := range g.successorsSeq(current) {
+			alternative := distances[current] + weight
+			if alternative < distances[neighbor] {
+				distances[neighbor] = alternative
+				previous[neighbor] = current
+			}
+		}
+	}
+
+	return distances, previous
+}
+
+// build a width x height grid graph where each node is connected to
+// its cardinal neighbors. weights vary a bit so ties are uncommon
+func buildBenchmarkGrid(width, height int) *UndirectedGraph[int] {
+	g := NewUndirectedGraph[int]()
+	for y := range height {
+		for x := range width {
+			u := Node[int]{y*width + x}
+			if x+1 < width {
+				g.AddEdge(u, Node[int]{y*width + x + 1}, float64(1+(x*y)%7))
+			}
+			if y+1 < height {
+				g.AddEdge(u, Node[int]{(y+1)*width + x}, float64(1+(x+y)%5))
+			}
+		}
+	}
+	return g
+}
+
+func TestDijkstraMatchesLinearScan(t *testing.T) {
+	// compare the heap-based implementation against the reference
+	g := buildBenchmarkGrid(30, 30)
+	start := Node[int]{0}
+
+	distances, _ := g.Dijkstra(start)
+	expected, _ := dijkstraLinearScan(&g.graphData, start)
+
+	for node, d := range expected {
+		if distances[node] != d {
+			t.Errorf("Dijkstra expected distance %f to %v, got %f", d, node, distances[node])
+		}
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	g := buildBenchmarkGrid(500, 500)
+	start := Node[int]{0}
+	for b.Loop() {
+		g.Dijkstra(start)
+	}
+}
+
+func BenchmarkDijkstraLinearScan(b *testing.B) {
+	g := buildBenchmarkGrid(500, 500)
+	start := Node[int]{0}
+	for b.Loop() {
+		dijkstraLinearScan(&g.graphData, start)
+	}
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewUndirectedGraph[int]()
+	u, v, w, x, y, z := getNodes()
+
+	// a square with one diagonal, plus a separate pair
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 2.0)
+	g.AddEdge(w, x, 3.0)
+	g.AddEdge(x, u, 4.0)
+	g.AddEdge(u, w, 2.5)
+	g.AddEdge(y, z, 7.0)
+
+	tree, total := g.MinimumSpanningTree()
+
+	t.Run("MST total weight", func(t *testing.T) {
+		// 1.0 + 2.0 + 3.0 for the square, 7.0 for the pair
+		if total != 13.0 {
+			t.Errorf("Expected total weight of 13.0, got %f", total)
+		}
+	})
+
+	t.Run("MST spans all nodes", func(t *testing.T) {
+		if n := tree.NumberOfNodes(); n != 6 {
+			t.Errorf("Expected 6 nodes, got %d", n)
+		}
+		// a spanning forest has n - components edges
+		if n := tree.NumberOfEdges(); n != 4 {
+			t.Errorf("Expected 4 edges, got %d", n)
+		}
+		if tree.HasCycle() {
+			t.Errorf("Expected spanning forest to be acyclic")
+		}
+	})
+
+	t.Run("MST leaves original untouched", func(t *testing.T) {
+		if !g.HasEdge(x, u) || !tree.HasEdge(u, v) || tree.HasEdge(x, u) {
+			t.Errorf("Expected MST to be a separate graph without the heaviest square edge")
+		}
+	})
+}
+
+func TestReconstructPath(t *testing.T) {
+	g := NewDirectedGraph[int]()
+	u, v, w, x, _, _ := getNodes()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 1.0)
+	g.AddNode(x)
+
+	// run Dijkstra once, reconstruct several targets
+	_, previous := g.Dijkstra(u)
+
+	t.Run("Reachable target", func(t *testing.T) {
+		path, ok := ReconstructPath(previous, u, w)
+		if !ok || !slices.Equal(path, Path[int]{u, v, w}) {
+			t.Errorf("Expected path [u v w], got %v and %t", path, ok)
+		}
+	})
+
+	t.Run("Path to self", func(t *testing.T) {
+		path, ok := ReconstructPath(previous, u, u)
+		if !ok || !slices.Equal(path, Path[int]{u}) {
+			t.Errorf("Expected path [u], got %v and %t", path, ok)
+		}
+	})
+
+	t.Run("Unreachable target", func(t *testing.T) {
+		path, ok := ReconstructPath(previous, u, x)
+		if ok || path == nil || len(path) != 0 {
+			t.Errorf("Expected empty path and false, got %v and %t", path, ok)
+		}
+	})
+
+	t.Run("Missing parent", func(t *testing.T) {
+		// w's parent v has no parent of its own
+		broken := Paths[int]{w: v}
+		path, ok := ReconstructPath(broken, u, w)
+		if ok || path == nil || len(path) != 0 {
+			t.Errorf("Expected empty path and false, got %v and %t", path, ok)
+		}
+	})
+
+	t.Run("Cycle in the previous map", func(t *testing.T) {
+		// the chain back from w loops between v and w and never reaches u
+		broken := Paths[int]{w: v, v: w, u: u}
+		path, ok := ReconstructPath(broken, u, w)
+		if ok || path == nil || len(path) != 0 {
+			t.Errorf("Expected empty path and false, got %v and %t", path, ok)
+		}
+	})
+}
+
+func TestDijkstraToFunc(t *testing.T) {
+	// a corridor with exits at both ends, the right one being closer
+	g := GridGraph(7, 1)
+	start := Node[Coordinate]{Coordinate{2, 0}}
+	isExit := func(n Node[Coordinate]) bool {
+		return n.ID.X == 0 || n.ID.X == 6 || n.ID.X == 5
+	}
+
+	t.Run("Closest goal", func(t *testing.T) {
+		path, l, cost := g.DijkstraToFunc(start, func(n Node[Coordinate]) bool {
+			return n.ID.X == 0 || n.ID.X == 6
+		})
+		if l != 3 || cost != 2.0 || path[l-1] != (Node[Coordinate]{Coordinate{0, 0}}) {
+			t.Errorf("Expected path of 3 nodes to the left exit, got %v, %d, and %f", path, l, cost)
+		}
+	})
+
+	t.Run("Start is a goal", func(t *testing.T) {
+		path, l, cost := g.DijkstraToFunc(start, func(n Node[Coordinate]) bool { return true })
+		if !slices.Equal(path, Path[Coordinate]{start}) || l != 1 || cost != 0.0 {
+			t.Errorf("Expected trivial path, got %v, %d, and %f", path, l, cost)
+		}
+	})
+
+	t.Run("Weighted edges", func(t *testing.T) {
+		// make the way left expensive, so the right exits win
+		h := GridGraph(7, 1)
+		h.AddEdge(start, Node[Coordinate]{Coordinate{1, 0}}, 10.0)
+		path, l, cost := h.DijkstraToFunc(start, isExit)
+		if l != 4 || cost != 3.0 || path[l-1] != (Node[Coordinate]{Coordinate{5, 0}}) {
+			t.Errorf("Expected path of 4 nodes to (5, 0), got %v, %d, and %f", path, l, cost)
+		}
+	})
+
+	t.Run("No goal reachable", func(t *testing.T) {
+		path, l, cost := g.DijkstraToFunc(start, func(n Node[Coordinate]) bool { return false })
+		if path == nil || len(path) != 0 || l != 0 || !math.IsInf(cost, 1) {
+			t.Errorf("Expected empty path, length 0, and infinite cost, got %v, %d, and %f", path, l, cost)
+		}
+	})
+}
+
+func TestMultiSource(t *testing.T) {
+	// a corridor with sources at both ends
+	g := GridGraph(7, 1)
+	left, right := Node[Coordinate]{Coordinate{0, 0}}, Node[Coordinate]{Coordinate{6, 0}}
+	starts := []Node[Coordinate]{left, right}
+
+	searches := map[string]func([]Node[Coordinate]) (Distances[Coordinate], Paths[Coordinate]){
+		"BFS":      g.MultiSourceBFS,
+		"Dijkstra": g.MultiSourceDijkstra,
+	}
+	for name, search := range searches {
+		t.Run(name, func(t *testing.T) {
+			distances, previous := search(starts)
+			for x := range 7 {
+				n := Node[Coordinate]{Coordinate{x, 0}}
+				expected := float64(min(x, 6-x))
+				if distances[n] != expected {
+					t.Errorf("Expected distance %f to %v, got %f", expected, n, distances[n])
+				}
+			}
+			// each node leads back to its nearest source
+			if path, ok := ReconstructPath(previous, right, Node[Coordinate]{Coordinate{4, 0}}); !ok || len(path) != 3 {
+				t.Errorf("Expected path of 3 nodes from the right source, got %v", path)
+			}
+			if previous[left] != left || previous[right] != right {
+				t.Errorf("Expected sources to be their own previous node")
+			}
+		})
+	}
+
+	t.Run("Unreachable nodes", func(t *testing.T) {
+		h := PathGraph([]int{1, 2, 3})
+		h.AddNode(Node[int]{4})
+		distances, _ := h.MultiSourceBFS([]Node[int]{{1}, {3}})
+		if !math.IsInf(distances[Node[int]{4}], 1) || distances[Node[int]{2}] != 1.0 {
+			t.Errorf("Expected infinite distance to 4 and 1 to 2, got %v", distances)
+		}
+	})
+}
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	t.Run("Matches Dijkstra on a grid", func(t *testing.T) {
+		g := buildBenchmarkGrid(20, 20)
+		start := Node[int]{0}
+		for _, target := range []Node[int]{{0}, {1}, {19}, {210}, {399}} {
+			_, expectedL, expectedCost := g.DijkstraTo(start, target)
+			path, l, cost := g.BidirectionalDijkstra(start, target)
+			if cost != expectedCost {
+				t.Errorf("Expected cost %f to %v, got %f", expectedCost, target, cost)
+			}
+			if l != len(path) || path[0] != start || path[l-1] != target {
+				t.Errorf("Expected path from %v to %v, got %v", start, target, path)
+			}
+			if pathCost, err := path.Cost(g); err != nil || pathCost != cost {
+				t.Errorf("Expected path to cost %f, got %f and %v", cost, pathCost, err)
+			}
+			if l == 0 && expectedL != 0 {
+				t.Errorf("Expected a path to %v", target)
+			}
+		}
+	})
+
+	t.Run("Matches Dijkstra on random directed graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(3, 4))
+		for range 20 {
+			g := NewDirectedGraph[int]()
+			for u := range 30 {
+				g.AddNode(Node[int]{u})
+				for v := range 30 {
+					if u != v && rng.Float64() < 0.08 {
+						g.AddEdge(Node[int]{u}, Node[int]{v}, float64(1+rng.IntN(9)))
+					}
+				}
+			}
+			for target := range 30 {
+				_, expectedL, expectedCost := g.DijkstraTo(Node[int]{0}, Node[int]{target})
+				path, l, cost := g.BidirectionalDijkstra(Node[int]{0}, Node[int]{target})
+				if cost != expectedCost || (l == 0) != (expectedL == 0) {
+					t.Fatalf("Expected cost %f to %d, got %f with path %v", expectedCost, target, cost, path)
+				}
+				if pathCost, ok := path.PathWeight(g); l > 0 && (!ok || pathCost != cost) {
+					t.Fatalf("Expected valid path to %d costing %f, got %v", target, cost, path)
+				}
+			}
+		}
+	})
+
+	t.Run("Unreachable target", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		u, v, w, _, _, z := getNodes()
+		g.AddEdge(u, v, 1.0)
+		g.AddNode(w)
+		for _, target := range []Node[int]{w, z} {
+			path, l, cost := g.BidirectionalDijkstra(u, target)
+			if path == nil || len(path) != 0 || l != 0 || !math.IsInf(cost, 1) {
+				t.Errorf("Expected empty path, length 0, and infinite cost, got %v, %d, and %f", path, l, cost)
+			}
+		}
+	})
+}
+
+func BenchmarkBidirectionalDijkstra(b *testing.B) {
+	g := buildBenchmarkGrid(500, 500)
+	start, target := Node[int]{0}, Node[int]{250*500 + 250}
+	for b.Loop() {
+		g.BidirectionalDijkstra(start, target)
+	}
+}
+
+func BenchmarkDijkstraTo(b *testing.B) {
+	g := buildBenchmarkGrid(500, 500)
+	start, target := Node[int]{0}, Node[int]{250*500 + 250}
+	for b.Loop() {
+		g.DijkstraTo(start, target)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	t.Run("Matches Dijkstra", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4, 5})
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{2}, 4.0)
+		g.AddNode(Node[int]{6})
+		expected, _ := g.Dijkstra(Node[int]{1})
+		distances, _, err := g.BellmanFord(Node[int]{1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !maps.Equal(distances, expected) {
+			t.Errorf("Expected %v, got %v", expected, distances)
+		}
+	})
+
+	t.Run("Negative weights", func(t *testing.T) {
+		// the detour through the negative edge is cheaper
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 2.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 5.0)
+		g.AddEdge(Node[int]{3}, Node[int]{2}, -4.0)
+		distances, previous, err := g.BellmanFord(Node[int]{1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if distances[Node[int]{2}] != 1.0 {
+			t.Errorf("Expected distance 1, got %f", distances[Node[int]{2}])
+		}
+		path, _ := ReconstructPath(previous, Node[int]{1}, Node[int]{2})
+		if expected := (Path[int]{{1}, {3}, {2}}); !slices.Equal(path, expected) {
+			t.Errorf("Expected %v, got %v", expected, path)
+		}
+	})
+
+	t.Run("Negative cycle", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, -2.0)
+		g.AddEdge(Node[int]{3}, Node[int]{2}, 1.0)
+		if _, _, err := g.BellmanFord(Node[int]{1}); !errors.Is(err, ErrNegativeCycle) {
+			t.Errorf("Expected ErrNegativeCycle, got %v", err)
+		}
+		if _, _, err := g.BellmanFord(Node[int]{3}); !errors.Is(err, ErrNegativeCycle) {
+			t.Errorf("Expected ErrNegativeCycle, got %v", err)
+		}
+		// a cycle that can't be reached doesn't matter
+		g.AddNode(Node[int]{4})
+		if _, _, err := g.BellmanFord(Node[int]{4}); err != nil {
+			t.Errorf("Expected no error for unreachable cycle, got %v", err)
+		}
+		if _, _, err := g.BellmanFord(Node[int]{5}); err == nil {
+			t.Errorf("Expected error for missing start node")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package graph
+
+import "maps"
+
+// nodes are map keys, so they can't carry anything besides their ID
+// without changing what makes two nodes the same. metadata lives in a
+// store next to the adjacencies instead, keyed by ID. it only holds
+// entries for nodes in the graph, removing a node drops its attributes
+
+// function to set an attribute of a node, replacing any previous value
+// under the same key. returns false and does nothing if the node isn't
+// in the graph
+func (g *graphData[K]) SetNodeAttribute(n Node[K], key string, value any) bool {
+	if !g.HasNode(n) {
+		return false
+	}
+	if g.attributes == nil {
+		g.attributes = make(map[K]map[string]any)
+	}
+	g.logAttribute(n.ID, key)
+	if g.attributes[n.ID] == nil {
+		g.attributes[n.ID] = make(map[string]any)
+	}
+	g.attributes[n.ID][key] = value
+	return true
+}
+
+// function to look up an attribute of a node, and whether it was set
+func (g *graphData[K]) NodeAttribute(n Node[K], key string) (any, bool) {
+	value, ok := g.attributes[n.ID][key]
+	return value, ok
+}
+
+// function to return all attributes of a node. the map is a copy, so
+// changing it doesn't change the graph. nodes without attributes get
+// an empty map
+func (g *graphData[K]) NodeAttributes(n Node[K]) map[string]any {
+	attributes := make(map[string]any, len(g.attributes[n.ID]))
+	maps.Copy(attributes, g.attributes[n.ID])
+	return attributes
+}
+
+// function to remove an attribute from a node
+func (g *graphData[K]) RemoveNodeAttribute(n Node[K], key string) {
+	g.logAttribute(n.ID, key)
+	delete(g.attributes[n.ID], key)
+	if len(g.attributes[n.ID]) == 0 {
+		delete(g.attributes, n.ID)
+	}
+}
+
+// helper to copy the attributes of a node from another graph, stored
+// under the ID of to. the values themselves are shared, not copied
+func (g *graphData[K]) copyAttributes(from *graphData[K], n, to Node[K]) {
+	for key, value := range from.attributes[n.ID] {
+		g.SetNodeAttribute(to, key, value)
+	}
+}
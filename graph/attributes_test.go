@@ -0,0 +1,82 @@
+package graph
+
+import "testing"
+
+func TestNodeAttributes(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Set and look up", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+
+		if !g.SetNodeAttribute(u, "color", "red") {
+			t.Errorf("Expected to set an attribute on u")
+		}
+		if value, ok := g.NodeAttribute(u, "color"); !ok || value != "red" {
+			t.Errorf("Expected color red, got %v and %t", value, ok)
+		}
+		if _, ok := g.NodeAttribute(v, "color"); ok {
+			t.Errorf("Expected no color on v")
+		}
+		// nodes that aren't in the graph can't have attributes
+		if g.SetNodeAttribute(w, "color", "blue") {
+			t.Errorf("Expected no attribute on a missing node")
+		}
+		if g.HasNode(w) {
+			t.Errorf("Expected setting an attribute not to add a node")
+		}
+	})
+
+	t.Run("All attributes are a copy", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddNode(u)
+		g.SetNodeAttribute(u, "color", "red")
+		g.SetNodeAttribute(u, "size", 3)
+
+		attributes := g.NodeAttributes(u)
+		if len(attributes) != 2 || attributes["size"] != 3 {
+			t.Errorf("Expected 2 attributes, got %v", attributes)
+		}
+		attributes["size"] = 4
+		if value, _ := g.NodeAttribute(u, "size"); value != 3 {
+			t.Errorf("Expected size to stay 3, got %v", value)
+		}
+		if attributes := g.NodeAttributes(v); attributes == nil || len(attributes) != 0 {
+			t.Errorf("Expected empty attributes for v, got %v", attributes)
+		}
+
+		g.RemoveNodeAttribute(u, "color")
+		if _, ok := g.NodeAttribute(u, "color"); ok {
+			t.Errorf("Expected color to be removed")
+		}
+	})
+
+	t.Run("Attributes follow the node", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.SetNodeAttribute(u, "color", "red")
+
+		c := g.Copy()
+		if value, _ := c.NodeAttribute(u, "color"); value != "red" {
+			t.Errorf("Expected the copy to keep the color, got %v", value)
+		}
+		c.SetNodeAttribute(u, "color", "blue")
+		if value, _ := g.NodeAttribute(u, "color"); value != "red" {
+			t.Errorf("Expected the original to keep its color, got %v", value)
+		}
+
+		if err := g.Relabel(func(id int) int { return id * 10 }); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if value, _ := g.NodeAttribute(Node[int]{10}, "color"); value != "red" {
+			t.Errorf("Expected the relabeled node to keep its color, got %v", value)
+		}
+
+		// removing the node drops its attributes, adding it back starts over
+		g.RemoveNode(Node[int]{10})
+		g.AddNode(Node[int]{10})
+		if _, ok := g.NodeAttribute(Node[int]{10}, "color"); ok {
+			t.Errorf("Expected attributes to be gone with the node")
+		}
+	})
+}
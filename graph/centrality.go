@@ -0,0 +1,209 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// calculate the PageRank of every node using power iteration. each
+// round, a node passes the damping share of its rank evenly on to its
+// successors, and the rest is spread over all nodes. nodes without
+// successors spread all of their rank over all nodes. iteration stops
+// after the given number of rounds, or earlier once the total change
+// in rank drops below tolerance. the scores sum to 1.0
+func (g *graphData[K]) PageRank(damping float64, iterations int, tolerance float64) map[Node[K]]float64 {
+	n := float64(len(g.Adjacencies))
+	teleport := make(map[Node[K]]float64, len(g.Adjacencies))
+	for node := range g.NodesSeq() {
+		teleport[node] = 1.0 / n
+	}
+	return g.pageRank(teleport, damping, iterations, tolerance)
+}
+
+// calculate PageRank like PageRank, but jumping only to the seed nodes
+// instead of to any node. rank that isn't passed along an edge goes
+// back to the seeds in equal parts, so nodes close to the seeds come
+// out ahead. runs the given number of rounds. the scores sum to 1.0,
+// and it errors if there are no seeds or one isn't in the graph
+func (g *graphData[K]) PersonalizedPageRank(seeds []Node[K], damping float64, iterations int) (map[Node[K]]float64, error) {
+	if len(seeds) == 0 {
+		return nil, errors.New("no seed nodes")
+	}
+	teleport := make(map[Node[K]]float64, len(seeds))
+	for _, seed := range seeds {
+		if !g.HasNode(seed) {
+			return nil, fmt.Errorf("seed node %v not in graph", seed.ID)
+		}
+		teleport[seed] = 0.0
+	}
+	// seeds given more than once still count once
+	for seed := range teleport {
+		teleport[seed] = 1.0 / float64(len(teleport))
+	}
+	return g.pageRank(teleport, damping, iterations, 0.0), nil
+}
+
+// helper to run the PageRank power iteration. teleport holds the share
+// of the jumps, and of the rank of nodes without successors, that each
+// node gets. nodes missing from it get none
+func (g *graphData[K]) pageRank(teleport map[Node[K]]float64, damping float64, iterations int, tolerance float64) map[Node[K]]float64 {
+	rank := make(map[Node[K]]float64)
+	if len(g.Adjacencies) == 0 {
+		return rank
+	}
+
+	// start out with the teleport distribution
+	for node := range g.NodesSeq() {
+		rank[node] = teleport[node]
+	}
+
+	for range iterations {
+		// collect the rank of nodes that can't pass it on
+		dangling := 0.0
+		for node := range g.NodesSeq() {
+			if g.OutDegree(node) == 0 {
+				dangling += rank[node]
+			}
+		}
+
+		// every node gets its teleport share and cut of the dangling rank
+		next := make(map[Node[K]]float64)
+		for node := range g.NodesSeq() {
+			next[node] = ((1.0 - damping) + damping*dangling) * teleport[node]
+		}
+		// and whatever its predecessors pass on to it
+		for node := range g.NodesSeq() {
+			if g.OutDegree(node) == 0 {
+				continue
+			}
+			share := damping * rank[node] / float64(g.OutDegree(node))
+			for neighbor := range g.successorsSeq(node) {
+				next[neighbor] += share
+			}
+		}
+
+		// measure how much the ranks moved
+		change := 0.0
+		for node := range g.NodesSeq() {
+			change += math.Abs(next[node] - rank[node])
+		}
+		rank = next
+		if change < tolerance {
+			break
+		}
+	}
+
+	return rank
+}
+
+// helper to calculate edge betweenness with Brandes' algorithm, counting
+// shortest paths in steps, ignoring weights. a BFS from every node
+// counts the shortest paths to every other node, then walks back from
+// the furthest nodes and hands each edge its share of the paths that
+// run through it. edges maps both orientations of an edge to the key
+// it's reported under
+func (g *graphData[K]) edgeBetweenness(edges map[[2]Node[K]]Edge[K]) map[Edge[K]]float64 {
+	betweenness := make(map[Edge[K]]float64, len(edges))
+	for _, e := range edges {
+		betweenness[e] = 0.0
+	}
+
+	for s := range g.NodesSeq() {
+		// nodes in the order they were reached, their distance, the
+		// number of shortest paths to them, and where those come from
+		order := make([]Node[K], 0)
+		dist := map[Node[K]]int{s: 0}
+		paths := map[Node[K]]float64{s: 1.0}
+		previous := make(map[Node[K]][]Node[K])
+
+		queue := Queue[K]{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			order = append(order, v)
+			for w := range g.successorsSeq(v) {
+				if _, ok := dist[w]; !ok {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					paths[w] += paths[v]
+					previous[w] = append(previous[w], v)
+				}
+			}
+		}
+
+		// hand out the dependencies from the furthest nodes inwards
+		dependency := make(map[Node[K]]float64)
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range previous[w] {
+				share := paths[v] / paths[w] * (1.0 + dependency[w])
+				betweenness[edges[[2]Node[K]{v, w}]] += share
+				dependency[v] += share
+			}
+		}
+	}
+	return betweenness
+}
+
+// function to calculate the edge betweenness of every edge, the number
+// of shortest paths between pairs of nodes that run through it. where
+// a pair has several shortest paths, each counts for an equal fraction.
+// paths are counted in steps, so weights are ignored, and self-loops
+// are never on a shortest path
+func (g *DirectedGraph[K]) EdgeBetweenness() map[Edge[K]]float64 {
+	edges := make(map[[2]Node[K]]Edge[K])
+	for e := range g.EdgesSeq() {
+		edges[[2]Node[K]{e.u, e.v}] = e
+	}
+	return g.edgeBetweenness(edges)
+}
+
+// like the directed version. edges are keyed the way Edges reports
+// them, and every pair of nodes is only counted once, not once from
+// each end
+func (g *UndirectedGraph[K]) EdgeBetweenness() map[Edge[K]]float64 {
+	edges := make(map[[2]Node[K]]Edge[K])
+	for _, e := range g.Edges() {
+		edges[[2]Node[K]{e.u, e.v}] = e
+		edges[[2]Node[K]{e.v, e.u}] = e
+	}
+	betweenness := g.edgeBetweenness(edges)
+	for e := range betweenness {
+		betweenness[e] /= 2.0
+	}
+	return betweenness
+}
+
+// function to split an undirected graph into k communities with the
+// Girvan-Newman method: the edge with the highest betweenness, the one
+// most shortest paths squeeze through, is removed until the graph falls
+// apart into at least k connected components. ties go to the first edge
+// in the order of SortedEdges. betweenness is recalculated after every
+// removal, so this is slow on large graphs. the graph itself isn't
+// changed. returns the components with their nodes sorted, sorted by
+// their first node. if the graph already has k or more components,
+// those are returned, and it can't be split further than into single
+// nodes
+func (g *UndirectedGraph[K]) GirvanNewmanCommunities(k int) [][]Node[K] {
+	h := &UndirectedGraph[K]{graphData: *g.Copy()}
+	// self-loops don't hold anything together
+	h.RemoveSelfLoops()
+
+	for h.NumberOfComponents() < k && h.NumberOfEdges() > 0 {
+		betweenness := h.EdgeBetweenness()
+		var worst Edge[K]
+		highest := -1.0
+		for _, e := range h.SortedEdges(nil) {
+			// sums come out in map order, so allow for rounding
+			if b := betweenness[e]; b > highest+1e-9*max(1.0, highest) {
+				worst, highest = e, b
+			}
+		}
+		h.RemoveEdge(worst.u, worst.v)
+	}
+
+	return sortedComponents(h.ConnectedComponents())
+}
@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPageRank(t *testing.T) {
+	g := NewDirectedGraph[int]()
+	u, v, w, x, y, _ := getNodes()
+
+	// everything links to u, which links on to v. y is dangling
+	g.AddEdge(v, u, 1.0)
+	g.AddEdge(w, u, 1.0)
+	g.AddEdge(x, u, 1.0)
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(x, y, 1.0)
+
+	rank := g.PageRank(0.85, 100, 1e-10)
+
+	t.Run("PageRank sums to one", func(t *testing.T) {
+		sum := 0.0
+		for _, r := range rank {
+			sum += r
+		}
+		if math.Abs(sum-1.0) > 1e-9 {
+			t.Errorf("Expected ranks to sum to 1.0, got %f", sum)
+		}
+	})
+
+	t.Run("PageRank favors linked nodes", func(t *testing.T) {
+		if rank[u] <= rank[v] || rank[v] <= rank[w] {
+			t.Errorf("Expected rank of u > v > w, got %f, %f, %f", rank[u], rank[v], rank[w])
+		}
+		if rank[w] != rank[x] {
+			t.Errorf("Expected nodes without in-edges to have equal rank, got %f and %f", rank[w], rank[x])
+		}
+	})
+
+	t.Run("PageRank on empty graph", func(t *testing.T) {
+		h := NewDirectedGraph[int]()
+		if r := h.PageRank(0.85, 100, 1e-10); len(r) != 0 {
+			t.Errorf("Expected no ranks, got %v", r)
+		}
+	})
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	g := PathGraph([]int{1, 2, 3, 4, 5})
+
+	t.Run("Favors nodes near the seeds", func(t *testing.T) {
+		rank, err := g.PersonalizedPageRank([]Node[int]{{1}}, 0.85, 100)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		sum := 0.0
+		for _, r := range rank {
+			sum += r
+		}
+		if math.Abs(sum-1.0) > 1e-9 {
+			t.Errorf("Expected ranks to sum to 1.0, got %f", sum)
+		}
+		if rank[Node[int]{2}] <= rank[Node[int]{4}] || rank[Node[int]{3}] <= rank[Node[int]{5}] {
+			t.Errorf("Expected nodes closer to the seed to rank higher, got %v", rank)
+		}
+	})
+
+	t.Run("All seeds is plain PageRank", func(t *testing.T) {
+		rank, err := g.PersonalizedPageRank(g.Nodes(), 0.85, 50)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		plain := g.PageRank(0.85, 50, 0.0)
+		for n, r := range plain {
+			if math.Abs(rank[n]-r) > 1e-12 {
+				t.Errorf("Expected rank %f for %v, got %f", r, n, rank[n])
+			}
+		}
+	})
+
+	t.Run("Invalid seeds", func(t *testing.T) {
+		if _, err := g.PersonalizedPageRank([]Node[int]{{6}}, 0.85, 10); err == nil {
+			t.Errorf("Expected error for seed not in graph")
+		}
+		if _, err := g.PersonalizedPageRank(nil, 0.85, 10); err == nil {
+			t.Errorf("Expected error without seeds")
+		}
+	})
+}
+
+func TestEdgeBetweenness(t *testing.T) {
+	t.Run("Undirected path", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4})
+		g.AddEdge(Node[int]{1}, Node[int]{1}, 1.0)
+		betweenness := g.EdgeBetweenness()
+		expected := map[[2]int]float64{{1, 2}: 3.0, {2, 3}: 4.0, {3, 4}: 3.0, {1, 1}: 0.0}
+		if len(betweenness) != len(expected) {
+			t.Errorf("Expected %d edges, got %v", len(expected), betweenness)
+		}
+		for pair, b := range expected {
+			e := NewEdge(Node[int]{pair[0]}, Node[int]{pair[1]}, 1.0)
+			if got, ok := betweenness[e]; !ok || got != b {
+				t.Errorf("Expected betweenness %f for %v, got %f", b, pair, got)
+			}
+		}
+	})
+
+	t.Run("Directed path", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		betweenness := g.EdgeBetweenness()
+		if b := betweenness[NewEdge(Node[int]{1}, Node[int]{2}, 1.0)]; b != 2.0 {
+			t.Errorf("Expected betweenness 2 for 1->2, got %f", b)
+		}
+		if b := betweenness[NewEdge(Node[int]{2}, Node[int]{3}, 1.0)]; b != 2.0 {
+			t.Errorf("Expected betweenness 2 for 2->3, got %f", b)
+		}
+	})
+
+	t.Run("Shortest paths split evenly", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4})
+		for e, b := range g.EdgeBetweenness() {
+			if math.Abs(b-2.0) > 1e-9 {
+				t.Errorf("Expected betweenness 2 for %v, got %f", e, b)
+			}
+		}
+	})
+}
+
+func TestGirvanNewmanCommunities(t *testing.T) {
+	// two triangles joined by a bridge
+	g := NewUndirectedGraph[int]()
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {1, 3}, {3, 4}, {4, 5}, {5, 6}, {4, 6}} {
+		g.AddEdge(Node[int]{e[0]}, Node[int]{e[1]}, 1.0)
+	}
+
+	t.Run("Two communities", func(t *testing.T) {
+		communities := g.GirvanNewmanCommunities(2)
+		expected := [][]Node[int]{{{1}, {2}, {3}}, {{4}, {5}, {6}}}
+		if !slices.EqualFunc(communities, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, communities)
+		}
+		// the graph itself is left alone
+		if g.NumberOfEdges() != 7 {
+			t.Errorf("Expected 7 edges to remain, got %d", g.NumberOfEdges())
+		}
+	})
+
+	t.Run("Already split", func(t *testing.T) {
+		if communities := g.GirvanNewmanCommunities(1); len(communities) != 1 || len(communities[0]) != 6 {
+			t.Errorf("Expected a single community, got %v", communities)
+		}
+	})
+
+	t.Run("Single nodes at most", func(t *testing.T) {
+		if communities := g.GirvanNewmanCommunities(10); len(communities) != 6 {
+			t.Errorf("Expected 6 communities, got %v", communities)
+		}
+	})
+}
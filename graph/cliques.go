@@ -0,0 +1,106 @@
+package graph
+
+import "slices"
+
+// function to find all maximal cliques of an undirected graph, the
+// groups of nodes that are all connected to each other and can't be
+// extended by another node. uses Bron-Kerbosch with pivoting. self-loops
+// are ignored, and a node without neighbors is a clique on its own.
+// each clique is sorted by ID, and the cliques are sorted among
+// themselves, so the result is the same from run to run
+func (g *UndirectedGraph[K]) MaximalCliques() [][]Node[K] {
+	cliques := make([][]Node[K], 0)
+	if len(g.Adjacencies) == 0 {
+		return cliques
+	}
+
+	// the neighbors of every node, without self-loops
+	neighbors := make(map[Node[K]]map[Node[K]]bool, len(g.Adjacencies))
+	for n := range g.NodesSeq() {
+		neighbors[n] = make(map[Node[K]]bool)
+		for _, m := range g.Neighbors(n) {
+			if m != n {
+				neighbors[n][m] = true
+			}
+		}
+	}
+
+	// helper to keep the members of a set that are neighbors of n
+	within := func(set map[Node[K]]bool, n Node[K]) map[Node[K]]bool {
+		result := make(map[Node[K]]bool)
+		for m := range set {
+			if neighbors[n][m] {
+				result[m] = true
+			}
+		}
+		return result
+	}
+
+	// r is the clique being built, p the nodes that could still extend
+	// it, and x the nodes that could too but were already tried. the
+	// recursion only goes as deep as the largest clique
+	var extend func(r []Node[K], p, x map[Node[K]]bool)
+	extend = func(r []Node[K], p, x map[Node[K]]bool) {
+		if len(p) == 0 {
+			// nothing left to add, and nothing tried before would have
+			// made it bigger, so it's maximal
+			if len(x) == 0 {
+				cliques = append(cliques, sortedNodes(slices.Clone(r)))
+			}
+			return
+		}
+
+		// pick the pivot with the most neighbors among the candidates.
+		// any clique has to include a node that isn't one of them
+		var pivot Node[K]
+		most := -1
+		for _, set := range []map[Node[K]]bool{p, x} {
+			for u := range set {
+				if count := len(within(p, u)); count > most {
+					pivot, most = u, count
+				}
+			}
+		}
+
+		candidates := make([]Node[K], 0, len(p))
+		for v := range p {
+			if !neighbors[pivot][v] {
+				candidates = append(candidates, v)
+			}
+		}
+		for _, v := range sortedNodes(candidates) {
+			extend(append(r, v), within(p, v), within(x, v))
+			delete(p, v)
+			x[v] = true
+		}
+	}
+
+	all := make(map[Node[K]]bool, len(g.Adjacencies))
+	for n := range g.NodesSeq() {
+		all[n] = true
+	}
+	extend(make([]Node[K], 0), all, make(map[Node[K]]bool))
+
+	slices.SortFunc(cliques, func(a, b []Node[K]) int {
+		for i := range min(len(a), len(b)) {
+			if c := compareIDs(a[i].ID, b[i].ID); c != 0 {
+				return c
+			}
+		}
+		return len(a) - len(b)
+	})
+	return cliques
+}
+
+// function to find a largest clique of an undirected graph. if there
+// are several of the same size, the first one MaximalCliques returns
+// wins. the empty graph has an empty largest clique
+func (g *UndirectedGraph[K]) LargestClique() []Node[K] {
+	largest := make([]Node[K], 0)
+	for _, clique := range g.MaximalCliques() {
+		if len(clique) > len(largest) {
+			largest = clique
+		}
+	}
+	return largest
+}
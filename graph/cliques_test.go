@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMaximalCliques(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	t.Run("Two overlapping triangles", func(t *testing.T) {
+		// u-v-w and v-w-x share the edge v-w
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(v, x, 1.0)
+		g.AddEdge(w, x, 1.0)
+		// self-loops don't matter
+		g.AddEdge(u, u, 1.0)
+
+		cliques := g.MaximalCliques()
+		expected := [][]Node[int]{{u, v, w}, {v, w, x}}
+		if !slices.EqualFunc(cliques, expected, slices.Equal) {
+			t.Errorf("Expected cliques %v, got %v", expected, cliques)
+		}
+	})
+
+	t.Run("Mixed sizes", func(t *testing.T) {
+		g := CompleteGraph([]int{1, 2, 3, 4})
+		g.AddEdge(x, y, 1.0)
+		g.AddNode(z)
+
+		cliques := g.MaximalCliques()
+		expected := [][]Node[int]{{u, v, w, x}, {x, y}, {z}}
+		if !slices.EqualFunc(cliques, expected, slices.Equal) {
+			t.Errorf("Expected cliques %v, got %v", expected, cliques)
+		}
+		if largest := g.LargestClique(); !slices.Equal(largest, expected[0]) {
+			t.Errorf("Expected largest clique %v, got %v", expected[0], largest)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		if cliques := g.MaximalCliques(); len(cliques) != 0 {
+			t.Errorf("Expected no cliques, got %v", cliques)
+		}
+		if largest := g.LargestClique(); len(largest) != 0 {
+			t.Errorf("Expected empty largest clique, got %v", largest)
+		}
+	})
+}
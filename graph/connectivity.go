@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// a node on the explicit DFS stack of the lowlink searches, with the
+// node it was reached from and the index of the next neighbor to visit
+type lowlinkFrame[K comparable] struct {
+	u, parent Node[K]
+	root      bool
+	neighbors []Node[K]
+	next      int
+}
+
+// helper to sort the nodes of each component, and the components by
+// their first node, so they come out the same from run to run
+func sortedComponents[K comparable](components [][]Node[K]) [][]Node[K] {
+	for i, component := range components {
+		components[i] = sortedNodes(component)
+	}
+	slices.SortFunc(components, func(a, b []Node[K]) int {
+		return compareIDs(a[0].ID, b[0].ID)
+	})
+	return components
+}
+
+// helper to run Tarjan's lowlink DFS over every component of the graph.
+// every node records when it was discovered, and its lowlink, the
+// earliest discovery time it can get back to through the edges below it
+// in the DFS tree. if edge isn't nil, it's called once for each edge,
+// as the DFS walks down it or finds it leads back up to an ancestor.
+// finish is called once the subtree below child is done, with
+// the lowlink of child, the discovery time of parent, and whether parent
+// is the root of its DFS tree. self-loops are skipped
+func (g *UndirectedGraph[K]) lowlinkDFS(edge func(u, v Node[K]), finish func(parent, child Node[K], low, discovered int, root bool)) {
+	discovered := make(map[Node[K]]int)
+	low := make(map[Node[K]]int)
+
+	for _, root := range sortedNodes(g.Nodes()) {
+		if _, ok := discovered[root]; ok {
+			continue
+		}
+		discovered[root], low[root] = len(discovered), len(discovered)
+		stack := []lowlinkFrame[K]{{u: root, root: true, neighbors: sortedNodes(g.loopFreeNeighbors(root))}}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(top.neighbors) {
+				v := top.neighbors[top.next]
+				top.next++
+				// the edge back up the tree isn't a way around it
+				if !top.root && v == top.parent {
+					continue
+				}
+				if d, ok := discovered[v]; ok {
+					// edges down to nodes already done were seen from below
+					if d < discovered[top.u] {
+						low[top.u] = min(low[top.u], d)
+						if edge != nil {
+							edge(top.u, v)
+						}
+					}
+					continue
+				}
+				if edge != nil {
+					edge(top.u, v)
+				}
+				discovered[v], low[v] = len(discovered), len(discovered)
+				stack = append(stack, lowlinkFrame[K]{u: v, parent: top.u, neighbors: sortedNodes(g.loopFreeNeighbors(v))})
+				continue
+			}
+
+			// done with this node, hand its lowlink up to its parent
+			done := *top
+			stack = stack[:len(stack)-1]
+			if done.root {
+				continue
+			}
+			p := done.parent
+			low[p] = min(low[p], low[done.u])
+			finish(p, done.u, low[done.u], discovered[p], len(stack) == 1)
+		}
+	}
+}
+
+// helper to make an edge between two nodes of an undirected graph,
+// oriented like Edges reports it
+func (g *UndirectedGraph[K]) orientedEdge(u, v Node[K]) Edge[K] {
+	if compareIDs(u.ID, v.ID) > 0 {
+		u, v = v, u
+	}
+	return NewEdge(u, v, g.Adjacencies[u.ID][v.ID])
+}
+
+// function to find the bridges of an undirected graph, the edges whose
+// removal splits a component in two. an edge down the DFS tree is a
+// bridge when nothing below it reaches back past it. edges are oriented
+// like Edges reports them, and sorted
+func (g *UndirectedGraph[K]) Bridges() []Edge[K] {
+	bridges := make([]Edge[K], 0)
+	g.lowlinkDFS(nil, func(parent, child Node[K], low, discovered int, root bool) {
+		if low > discovered {
+			bridges = append(bridges, g.orientedEdge(parent, child))
+		}
+	})
+	return sortedEdges(bridges)
+}
+
+// function to find the articulation points of an undirected graph, the
+// nodes whose removal splits a component in two. the root of a DFS tree
+// is one if it has more than one child, any other node if nothing below
+// one of its children reaches back past it. the nodes are sorted
+func (g *UndirectedGraph[K]) ArticulationPoints() []Node[K] {
+	points := make(map[Node[K]]bool)
+	children := make(map[Node[K]]int)
+	g.lowlinkDFS(nil, func(parent, child Node[K], low, discovered int, root bool) {
+		if root {
+			children[parent]++
+			if children[parent] > 1 {
+				points[parent] = true
+			}
+		} else if low >= discovered {
+			points[parent] = true
+		}
+	})
+	nodes := make([]Node[K], 0, len(points))
+	for n := range points {
+		nodes = append(nodes, n)
+	}
+	return sortedNodes(nodes)
+}
+
+// function to split the edges of an undirected graph into biconnected
+// components, the largest groups of edges that stay connected when any
+// single node is removed. edges are pushed on a stack as the DFS walks
+// them, and when nothing below a child reaches back past its parent,
+// the edges down to the child and below it make up a component. an
+// articulation point is part of every component it joins together.
+// self-loops and isolated nodes aren't in any component. edges are
+// oriented like Edges reports them, each component is sorted, and the
+// components are sorted by their first edge
+func (g *UndirectedGraph[K]) BiconnectedComponents() [][]Edge[K] {
+	components := make([][]Edge[K], 0)
+	stack := make([]Edge[K], 0)
+	g.lowlinkDFS(func(u, v Node[K]) {
+		stack = append(stack, g.orientedEdge(u, v))
+	}, func(parent, child Node[K], low, discovered int, root bool) {
+		if low < discovered {
+			return
+		}
+		// pop everything down to and including the edge to the child
+		last := g.orientedEdge(parent, child)
+		i := len(stack) - 1
+		for stack[i] != last {
+			i--
+		}
+		component := slices.Clone(stack[i:])
+		stack = stack[:i]
+		components = append(components, sortedEdges(component))
+	})
+	slices.SortFunc(components, func(a, b []Edge[K]) int {
+		return cmp.Or(compareIDs(a[0].u.ID, b[0].u.ID), compareIDs(a[0].v.ID, b[0].v.ID))
+	})
+	return components
+}
+
+// function to split an undirected graph into its 2-edge-connected
+// components, the groups of nodes that stay connected when any single
+// edge is removed. these are the components left over once all bridges
+// are gone. each component is sorted, and the components are sorted by
+// their first node. nodes only attached by bridges are on their own
+func (g *UndirectedGraph[K]) TwoEdgeConnectedComponents() [][]Node[K] {
+	h := &UndirectedGraph[K]{graphData: *g.Copy()}
+	for _, e := range g.Bridges() {
+		h.RemoveEdge(e.u, e.v)
+	}
+	return sortedComponents(h.ConnectedComponents())
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// helper to build two triangles joined by a bridge from 3 to 4
+func bridgedTriangles() *UndirectedGraph[int] {
+	g := NewUndirectedGraph[int]()
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {1, 3}, {3, 4}, {4, 5}, {5, 6}, {4, 6}} {
+		g.AddEdge(Node[int]{e[0]}, Node[int]{e[1]}, 1.0)
+	}
+	return g
+}
+
+func TestBridges(t *testing.T) {
+	t.Run("Two triangles", func(t *testing.T) {
+		bridges := bridgedTriangles().Bridges()
+		expected := []Edge[int]{NewEdge(Node[int]{3}, Node[int]{4}, 1.0)}
+		if !slices.Equal(bridges, expected) {
+			t.Errorf("Expected %v, got %v", expected, bridges)
+		}
+	})
+
+	t.Run("Every edge of a tree", func(t *testing.T) {
+		g := PathGraph([]int{3, 1, 2})
+		g.AddEdge(Node[int]{2}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{4}, Node[int]{5}, 2.0)
+		bridges := g.Bridges()
+		expected := []Edge[int]{
+			NewEdge(Node[int]{1}, Node[int]{2}, 1.0),
+			NewEdge(Node[int]{1}, Node[int]{3}, 1.0),
+			NewEdge(Node[int]{4}, Node[int]{5}, 2.0),
+		}
+		if !slices.Equal(bridges, expected) {
+			t.Errorf("Expected %v, got %v", expected, bridges)
+		}
+	})
+
+	t.Run("No bridges in a cycle", func(t *testing.T) {
+		if bridges := CycleGraph([]int{1, 2, 3, 4}).Bridges(); len(bridges) != 0 {
+			t.Errorf("Expected no bridges, got %v", bridges)
+		}
+	})
+}
+
+func TestTwoEdgeConnectedComponents(t *testing.T) {
+	t.Run("Two triangles", func(t *testing.T) {
+		components := bridgedTriangles().TwoEdgeConnectedComponents()
+		expected := [][]Node[int]{{{1}, {2}, {3}}, {{4}, {5}, {6}}}
+		if !slices.EqualFunc(components, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, components)
+		}
+	})
+
+	t.Run("Pendant node", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{3}, Node[int]{4}, 1.0)
+		components := g.TwoEdgeConnectedComponents()
+		expected := [][]Node[int]{{{1}, {2}, {3}}, {{4}}}
+		if !slices.EqualFunc(components, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, components)
+		}
+	})
+}
+
+func TestArticulationPoints(t *testing.T) {
+	t.Run("Two triangles", func(t *testing.T) {
+		points := bridgedTriangles().ArticulationPoints()
+		if !slices.Equal(points, []Node[int]{{3}, {4}}) {
+			t.Errorf("Expected [3 4], got %v", points)
+		}
+	})
+
+	t.Run("Root with several children", func(t *testing.T) {
+		points := StarGraph([]int{1, 2, 3, 4}).ArticulationPoints()
+		if !slices.Equal(points, []Node[int]{{1}}) {
+			t.Errorf("Expected [1], got %v", points)
+		}
+	})
+
+	t.Run("None in a cycle", func(t *testing.T) {
+		if points := CycleGraph([]int{1, 2, 3, 4}).ArticulationPoints(); len(points) != 0 {
+			t.Errorf("Expected no articulation points, got %v", points)
+		}
+	})
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	edges := func(pairs ...[2]int) []Edge[int] {
+		result := make([]Edge[int], 0, len(pairs))
+		for _, p := range pairs {
+			result = append(result, NewEdge(Node[int]{p[0]}, Node[int]{p[1]}, 1.0))
+		}
+		return result
+	}
+
+	t.Run("Figure eight", func(t *testing.T) {
+		// two triangles sharing node 3, which is in both components
+		g := CycleGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{3}, Node[int]{4}, 1.0)
+		g.AddEdge(Node[int]{4}, Node[int]{5}, 1.0)
+		g.AddEdge(Node[int]{5}, Node[int]{3}, 1.0)
+		g.AddEdge(Node[int]{1}, Node[int]{1}, 1.0)
+		g.AddNode(Node[int]{6})
+		components := g.BiconnectedComponents()
+		expected := [][]Edge[int]{
+			edges([2]int{1, 2}, [2]int{1, 3}, [2]int{2, 3}),
+			edges([2]int{3, 4}, [2]int{3, 5}, [2]int{4, 5}),
+		}
+		if !slices.EqualFunc(components, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, components)
+		}
+		if points := g.ArticulationPoints(); !slices.Equal(points, []Node[int]{{3}}) {
+			t.Errorf("Expected [3], got %v", points)
+		}
+	})
+
+	t.Run("Bridges are components of their own", func(t *testing.T) {
+		components := bridgedTriangles().BiconnectedComponents()
+		expected := [][]Edge[int]{
+			edges([2]int{1, 2}, [2]int{1, 3}, [2]int{2, 3}),
+			edges([2]int{3, 4}),
+			edges([2]int{4, 5}, [2]int{4, 6}, [2]int{5, 6}),
+		}
+		if !slices.EqualFunc(components, expected, slices.Equal) {
+			t.Errorf("Expected %v, got %v", expected, components)
+		}
+	})
+
+	t.Run("Square with a chord", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4})
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 1.0)
+		components := g.BiconnectedComponents()
+		if len(components) != 1 || len(components[0]) != 5 {
+			t.Errorf("Expected one component with all 5 edges, got %v", components)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// helper to write edges as CSV with a source,target,weight header.
+// edges are sorted by their end points
+func writeCSV[K comparable](w io.Writer, edges []Edge[K]) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"source", "target", "weight"}); err != nil {
+		return err
+	}
+	for _, e := range sortedEdges(edges) {
+		row := []string{DefaultLabel(e.u.ID), DefaultLabel(e.v.ID), strconv.FormatFloat(e.weight, 'g', -1, 64)}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// function to write the edges of a directed graph as CSV, one row per
+// edge under a source,target,weight header. nodes without any edges
+// have no row to go in, so they're left out
+func (g *DirectedGraph[K]) WriteCSV(w io.Writer) error {
+	return writeCSV(w, g.Edges())
+}
+
+// function to export a directed graph into a CSV file
+func (g *DirectedGraph[K]) ExportCSV(fname string) error {
+	return exportFile(fname, g.WriteCSV)
+}
+
+// like the directed version, with every undirected edge written once
+func (g *UndirectedGraph[K]) WriteCSV(w io.Writer) error {
+	return writeCSV(w, g.Edges())
+}
+
+// function to export an undirected graph into a CSV file
+func (g *UndirectedGraph[K]) ExportCSV(fname string) error {
+	return exportFile(fname, g.WriteCSV)
+}
+
+// function to read a graph from CSV, one edge per row as source, target,
+// and optionally weight, which defaults to 1.0. a first row of exactly
+// source,target,weight or source,target is taken as the header and
+// skipped. CSV doesn't say whether edges are directed, so the caller
+// does. parse turns the text of a node into its ID
+func ImportCSV[K comparable](r io.Reader, directed bool, parse func(string) (K, error)) (Graph[K], error) {
+	var g Graph[K]
+	if directed {
+		g = NewDirectedGraph[K]()
+	} else {
+		g = NewUndirectedGraph[K]()
+	}
+
+	reader := csv.NewReader(r)
+	// the weight column is optional, so rows may have 2 or 3 fields
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && isCSVHeader(row) {
+			continue
+		}
+		if len(row) != 2 && len(row) != 3 {
+			return nil, fmt.Errorf("line %d: expected 2 or 3 fields, got %d", line, len(row))
+		}
+
+		ends := make([]Node[K], 2)
+		for i := range ends {
+			id, err := parse(row[i])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			ends[i] = Node[K]{ID: id}
+		}
+		w := 1.0
+		if len(row) == 3 {
+			if w, err = strconv.ParseFloat(strings.TrimSpace(row[2]), 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid weight %q", line, row[2])
+			}
+		}
+		g.AddEdge(ends[0], ends[1], w)
+	}
+	return g, nil
+}
+
+// helper to recognize the header row of a CSV edge list
+func isCSVHeader(row []string) bool {
+	header := []string{"source", "target", "weight"}
+	if len(row) < 2 || len(row) > len(header) {
+		return false
+	}
+	for i, field := range row {
+		if !strings.EqualFold(strings.TrimSpace(field), header[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	identity := func(s string) (string, error) { return s, nil }
+
+	t.Run("Write directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[string]()
+		g.AddEdge(Node[string]{"b"}, Node[string]{"a, the first"}, 2.5)
+		g.AddEdge(Node[string]{"a, the first"}, Node[string]{"b"}, 1.0)
+
+		var buf bytes.Buffer
+		if err := g.WriteCSV(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "source,target,weight\n\"a, the first\",b,1\nb,\"a, the first\",2.5\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Undirected edges are written once", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+
+		var buf bytes.Buffer
+		if err := g.WriteCSV(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if buf.String() != "source,target,weight\n1,2,1\n" {
+			t.Errorf("Expected a single row, got %q", buf.String())
+		}
+	})
+
+	t.Run("Read with and without weights", func(t *testing.T) {
+		src := "Source, Target\n1,2\n2,3,4.5\n"
+		g, err := ImportCSV(strings.NewReader(src), true, strconv.Atoi)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := g.(*DirectedGraph[int]); !ok {
+			t.Fatalf("Expected a directed graph, got %T", g)
+		}
+		if weight, ok := g.EdgeWeight(Node[int]{1}, Node[int]{2}); !ok || weight != 1.0 {
+			t.Errorf("Expected 1->2 with default weight, got %f and %t", weight, ok)
+		}
+		if weight, ok := g.EdgeWeight(Node[int]{2}, Node[int]{3}); !ok || weight != 4.5 {
+			t.Errorf("Expected 2->3 with weight 4.5, got %f and %t", weight, ok)
+		}
+		if g.HasEdge(Node[int]{2}, Node[int]{1}) {
+			t.Errorf("Expected no edge 2->1")
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		g := NewUndirectedGraph[string]()
+		g.AddEdge(Node[string]{"a"}, Node[string]{`say "hi"`}, 2.0)
+		g.AddEdge(Node[string]{"b"}, Node[string]{"a"}, 0.5)
+
+		fname := filepath.Join(t.TempDir(), "edges.csv")
+		if err := g.ExportCSV(fname); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		f, _ := os.Open(fname)
+		defer f.Close()
+		h, err := ImportCSV(f, false, identity)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, h.Copy().Adjacencies) {
+			t.Errorf("Expected %v, got %v", g.Adjacencies, h.Copy().Adjacencies)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		cases := map[string]string{
+			"too few fields":  "a\n",
+			"too many fields": "a,b,1,2\n",
+			"invalid weight":  "1,2,x\n",
+			"invalid node":    "1,b,1\n",
+			"bad quoting":     "\"1,2\n",
+		}
+		for name, src := range cases {
+			if _, err := ImportCSV(strings.NewReader(src), true, strconv.Atoi); err == nil {
+				t.Errorf("Expected error for %s", name)
+			}
+		}
+	})
+}
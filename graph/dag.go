@@ -0,0 +1,120 @@
+package graph
+
+import "errors"
+
+// error returned by algorithms that only work on acyclic graphs
+var ErrNotAcyclic = errors.New("graph contains a cycle")
+
+// function to sort the nodes of a directed graph so that every edge
+// points from an earlier to a later node. uses Kahn's algorithm and
+// returns ErrNotAcyclic if the graph has a cycle
+func (g *DirectedGraph[K]) TopologicalSort() ([]Node[K], error) {
+	generations, err := g.TopologicalGenerations()
+	if err != nil {
+		return nil, err
+	}
+	// flatten the generations, they're already in order
+	order := make([]Node[K], 0, len(g.Adjacencies))
+	for _, generation := range generations {
+		order = append(order, generation...)
+	}
+	return order, nil
+}
+
+// function to group the nodes of a directed graph into generations.
+// the first generation holds the nodes nothing points to, and every
+// later one the nodes whose predecessors are all in earlier ones, so
+// the nodes of a generation can be processed in parallel. this is
+// Kahn's algorithm, taking each round of freed nodes as a layer.
+// returns ErrNotAcyclic if the graph has a cycle
+func (g *DirectedGraph[K]) TopologicalGenerations() ([][]Node[K], error) {
+	// count the incoming edges of every node, and start with all the
+	// nodes nothing points to
+	inDegree := make(map[Node[K]]int, len(g.Adjacencies))
+	current := make([]Node[K], 0)
+	for n := range g.NodesSeq() {
+		inDegree[n] = g.InDegree(n)
+		if inDegree[n] == 0 {
+			current = append(current, n)
+		}
+	}
+
+	generations := make([][]Node[K], 0)
+	count := 0
+	for len(current) > 0 {
+		generations = append(generations, current)
+		count += len(current)
+		// remove the generation's edges, and collect the nodes that
+		// are now free into the next one
+		next := make([]Node[K], 0)
+		for _, n := range current {
+			for neighbor := range g.successorsSeq(n) {
+				inDegree[neighbor]--
+				if inDegree[neighbor] == 0 {
+					next = append(next, neighbor)
+				}
+			}
+		}
+		current = next
+	}
+
+	// nodes on a cycle never lose all their incoming edges
+	if count != len(inDegree) {
+		return nil, ErrNotAcyclic
+	}
+	return generations, nil
+}
+
+// function to count the distinct paths from start to target in a
+// directed acyclic graph. walks the nodes in topological order, adding
+// up the ways to reach each one. returns ErrNotAcyclic on a cycle
+func (g *DirectedGraph[K]) CountPaths(start, target Node[K]) (int64, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return 0, err
+	}
+
+	// there's one way to get to the start, by not moving
+	ways := map[Node[K]]int64{start: 1}
+	for _, current := range order {
+		if ways[current] == 0 {
+			continue
+		}
+		for neighbor := range g.successorsSeq(current) {
+			ways[neighbor] += ways[current]
+		}
+	}
+	return ways[target], nil
+}
+
+// function to collapse every strongly connected component of a directed
+// graph into a single node. the result is always a DAG, with a node per
+// component numbered in topological order, and an edge between two
+// components whenever an edge ran between their members. edges inside a
+// component are dropped, and parallel edges between two components are
+// merged, keeping the smallest weight. also returns which component
+// each original node ended up in
+func (g *DirectedGraph[K]) Condensation() (*DirectedGraph[int], map[Node[K]]int) {
+	condensed := NewDirectedGraph[int]()
+	membership := make(map[Node[K]]int, len(g.Adjacencies))
+	for i, component := range g.StronglyConnectedComponents() {
+		condensed.AddNode(Node[int]{ID: i})
+		for _, n := range component {
+			membership[n] = i
+		}
+	}
+
+	for u := range g.NodesSeq() {
+		for v, w := range g.successorsSeq(u) {
+			cu, cv := Node[int]{ID: membership[u]}, Node[int]{ID: membership[v]}
+			if cu == cv {
+				continue
+			}
+			if existing, ok := condensed.EdgeWeight(cu, cv); ok && existing <= w {
+				continue
+			}
+			condensed.AddEdge(cu, cv, w)
+		}
+	}
+	return condensed, membership
+}
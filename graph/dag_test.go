@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirectedGraph[int]()
+	u, v, w, x, y, _ := getNodes()
+
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(u, w, 1.0)
+	g.AddEdge(v, x, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddNode(y)
+
+	t.Run("Topological order", func(t *testing.T) {
+		order, err := g.TopologicalSort()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(order) != 5 {
+			t.Fatalf("Expected 5 nodes, got %v", order)
+		}
+		// every edge points forward in the order
+		for _, e := range g.Edges() {
+			if slices.Index(order, e.u) > slices.Index(order, e.v) {
+				t.Errorf("Expected %v before %v, got %v", e.u, e.v, order)
+			}
+		}
+	})
+
+	t.Run("Topological sort with cycle", func(t *testing.T) {
+		h := NewDirectedGraph[int]()
+		h.AddEdge(u, v, 1.0)
+		h.AddEdge(v, u, 1.0)
+		if _, err := h.TopologicalSort(); !errors.Is(err, ErrNotAcyclic) {
+			t.Errorf("Expected ErrNotAcyclic, got %v", err)
+		}
+	})
+}
+
+func TestCountPaths(t *testing.T) {
+	g := NewDirectedGraph[int]()
+	u, v, w, x, y, z := getNodes()
+
+	// two diamonds in a row, plus a shortcut: 2 * 2 + 1 paths
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(u, w, 1.0)
+	g.AddEdge(v, x, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddEdge(x, y, 1.0)
+	g.AddEdge(x, z, 1.0)
+	g.AddEdge(y, Node[int]{7}, 1.0)
+	g.AddEdge(z, Node[int]{7}, 1.0)
+	g.AddEdge(u, Node[int]{7}, 1.0)
+
+	t.Run("Count paths in a DAG", func(t *testing.T) {
+		n, err := g.CountPaths(u, Node[int]{7})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if n != 5 {
+			t.Errorf("Expected 5 paths, got %d", n)
+		}
+	})
+
+	t.Run("Count paths against the edge direction", func(t *testing.T) {
+		n, _ := g.CountPaths(x, u)
+		if n != 0 {
+			t.Errorf("Expected 0 paths, got %d", n)
+		}
+	})
+
+	t.Run("Count paths with cycle", func(t *testing.T) {
+		g.AddEdge(Node[int]{7}, u, 1.0)
+		if _, err := g.CountPaths(u, x); !errors.Is(err, ErrNotAcyclic) {
+			t.Errorf("Expected ErrNotAcyclic, got %v", err)
+		}
+	})
+}
+
+func TestCondensation(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	// two cycles joined by two edges, plus a lone node
+	g := NewDirectedGraph[int]()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, u, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddEdge(x, w, 1.0)
+	g.AddEdge(u, w, 5.0)
+	g.AddEdge(v, x, 2.0)
+	g.AddNode(y)
+	g.AddEdge(z, z, 1.0)
+
+	condensed, membership := g.Condensation()
+	if condensed.NumberOfNodes() != 4 || condensed.NumberOfEdges() != 1 {
+		t.Errorf("Expected 4 nodes and 1 edge, got %d and %d", condensed.NumberOfNodes(), condensed.NumberOfEdges())
+	}
+	if membership[u] != membership[v] || membership[w] != membership[x] || membership[u] == membership[w] {
+		t.Errorf("Expected the two cycles to be separate components, got %v", membership)
+	}
+	// the two edges between the cycles are merged, keeping the cheaper one
+	from, to := Node[int]{membership[u]}, Node[int]{membership[w]}
+	if weight, ok := condensed.EdgeWeight(from, to); !ok || weight != 2.0 {
+		t.Errorf("Expected edge %v -> %v with weight 2.0, got %f", from, to, weight)
+	}
+	if condensed.HasCycle() {
+		t.Errorf("Expected the condensation to be acyclic")
+	}
+	// components are numbered in topological order
+	order, err := condensed.TopologicalSort()
+	if err != nil || membership[u] > membership[w] {
+		t.Errorf("Expected a topological order, got %v and %v", order, err)
+	}
+}
+
+func TestTopologicalGenerations(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	t.Run("Layers", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(v, x, 1.0)
+		g.AddEdge(w, x, 1.0)
+		// a shortcut doesn't pull x into an earlier generation
+		g.AddEdge(u, x, 1.0)
+		g.AddEdge(y, x, 1.0)
+		g.AddNode(z)
+
+		generations, err := g.TopologicalGenerations()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(generations) != 3 {
+			t.Fatalf("Expected 3 generations, got %v", generations)
+		}
+		expected := [][]Node[int]{{u, y, z}, {v, w}, {x}}
+		for i, generation := range generations {
+			slices.SortFunc(generation, func(a, b Node[int]) int { return compareIDs(a.ID, b.ID) })
+			if !slices.Equal(generation, expected[i]) {
+				t.Errorf("Expected generation %d to be %v, got %v", i, expected[i], generation)
+			}
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, v, 1.0)
+		if _, err := g.TopologicalGenerations(); !errors.Is(err, ErrNotAcyclic) {
+			t.Errorf("Expected ErrNotAcyclic, got %v", err)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		if generations, err := g.TopologicalGenerations(); err != nil || len(generations) != 0 {
+			t.Errorf("Expected no generations, got %v and %v", generations, err)
+		}
+	})
+}
@@ -12,18 +12,26 @@ func NewDirectedGraph[K comparable]() *DirectedGraph[K] {
 	}
 }
 
-// directed graphs add edges only in the indicated direction
+// directed graphs add edges only in the indicated direction. if self-
+// loops are disallowed, an edge from a node to itself is ignored
 func (g *DirectedGraph[K]) AddEdge(u, v Node[K], w float64) {
+	if u == v && g.noSelfLoops {
+		return
+	}
 	// add nodes to graph if they don't exist yet
-	if _, ok := g.Adjacencies[u]; !ok {
+	if _, ok := g.Adjacencies[u.ID]; !ok {
 		g.AddNode(u)
 	}
-	if _, ok := g.Adjacencies[v]; !ok {
+	if _, ok := g.Adjacencies[v.ID]; !ok {
 		g.AddNode(v)
 	}
 
 	// add the edge and adjancency
-	g.Adjacencies[u][v] = w
+	old, existed := g.Adjacencies[u.ID][v.ID]
+	g.setEdge(u, v, w)
+	if !existed || old != w {
+		g.notifyAddEdge(u, v, w)
+	}
 }
 
 // add from an iter of edges
@@ -33,14 +41,80 @@ func (g *DirectedGraph[K]) AddEdgesFrom(es []Edge[K]) {
 	}
 }
 
+// like AddEdgesFrom, but makes room for nodeHint nodes and all of the
+// edges first. see Reserve
+func (g *DirectedGraph[K]) AddEdgesFromWithHint(es []Edge[K], nodeHint int) {
+	g.Reserve(nodeHint, len(es))
+	g.AddEdgesFrom(es)
+}
+
 // remove an edge from a directed graph
 func (g *DirectedGraph[K]) RemoveEdge(u, v Node[K]) {
-	delete(g.Adjacencies[u], v)
+	w, ok := g.Adjacencies[u.ID][v.ID]
+	if !ok {
+		return
+	}
+	g.deleteEdge(u, v)
+	g.notifyRemoveEdge(u, v, w)
 }
 
 // remove edges from an undirected graph using an iter as the source
 func (g *DirectedGraph[K]) RemoveEdgesFrom(es []Edge[K]) {
 	for _, e := range es {
-		delete(g.Adjacencies[e.u], e.v)
+		g.RemoveEdge(e.u, e.v)
+	}
+}
+
+// function to create a new graph with the direction of every edge
+// flipped. weights and isolated nodes are kept, and the new graph
+// doesn't share any state with the original
+func (g *DirectedGraph[K]) Reverse() *DirectedGraph[K] {
+	r := NewDirectedGraph[K]()
+	for u := range g.NodesSeq() {
+		// make sure nodes without edges make it over
+		r.AddNode(u)
+		for v, w := range g.successorsSeq(u) {
+			r.AddEdge(v, u, w)
+		}
+	}
+	return r
+}
+
+// like MapWeights, but changes the weights of a copy of the graph and
+// returns that, leaving the graph itself alone
+func (g *DirectedGraph[K]) MappedWeights(f func(float64) float64) *DirectedGraph[K] {
+	h := &DirectedGraph[K]{graphData: *g.Copy()}
+	h.MapWeights(f)
+	return h
+}
+
+// function to create a new graph containing only the given nodes and
+// the edges between them. nodes that aren't in the graph are skipped
+func (g *DirectedGraph[K]) Subgraph(nodes []Node[K]) *DirectedGraph[K] {
+	return &DirectedGraph[K]{graphData: g.subgraph(nodes)}
+}
+
+// function to create a new graph containing only the nodes that keep
+// returns true for, and the edges between them. the graph itself is
+// left alone
+func (g *DirectedGraph[K]) FilterNodes(keep func(Node[K]) bool) *DirectedGraph[K] {
+	nodes := make([]Node[K], 0)
+	for n := range g.NodesSeq() {
+		if keep(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	return g.Subgraph(nodes)
+}
+
+// function to create a new graph containing all the nodes, but only the
+// edges that keep returns true for. the graph itself is left alone
+func (g *DirectedGraph[K]) FilterEdges(keep func(u, v Node[K], w float64) bool) *DirectedGraph[K] {
+	h := g.Subgraph(g.Nodes())
+	for e := range g.EdgesSeq() {
+		if !keep(e.u, e.v, e.weight) {
+			h.RemoveEdge(e.u, e.v)
+		}
 	}
+	return h
 }
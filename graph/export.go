@@ -0,0 +1,233 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// helper to create a file and hand it to a write function, making
+// sure errors from writing and closing the file are returned
+func exportFile(fname string, write func(io.Writer) error) error {
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// helper to sort nodes by ID so exports come out the same every time
+func sortedNodes[K comparable](nodes []Node[K]) []Node[K] {
+	slices.SortFunc(nodes, func(a, b Node[K]) int {
+		return compareIDs(a.ID, b.ID)
+	})
+	return nodes
+}
+
+// helper to sort edges by their end points
+func sortedEdges[K comparable](edges []Edge[K]) []Edge[K] {
+	return sortEdgesBy(edges, nil)
+}
+
+// function to render a node ID as a label in exports, using its default
+// format. this is what the exports without a label function use
+func DefaultLabel[K comparable](id K) string {
+	return fmt.Sprintf("%v", id)
+}
+
+// helper to write an edge list to a writer, one edge per line, with
+// node IDs rendered by label, or DefaultLabel if it's nil. stops at and
+// returns the first error encountered
+func writeEdgeList[K comparable](w io.Writer, edges []Edge[K], label func(K) string) error {
+	if label == nil {
+		label = DefaultLabel[K]
+	}
+	writer := bufio.NewWriter(w)
+	for _, e := range edges {
+		if _, err := fmt.Fprintf(writer, "'%s' '%s'\n", label(e.u.ID), label(e.v.ID)); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// function to write the edge list of a directed graph to a writer, one
+// edge per line. this can usually be imported by other graphing libraries
+func (g *DirectedGraph[K]) WriteEdgeList(w io.Writer) error {
+	return g.WriteEdgeListFunc(w, DefaultLabel[K])
+}
+
+// like WriteEdgeList, but node IDs are rendered by label. a nil label
+// function falls back to DefaultLabel
+func (g *DirectedGraph[K]) WriteEdgeListFunc(w io.Writer, label func(K) string) error {
+	return writeEdgeList(w, g.Edges(), label)
+}
+
+// function to export the edge list of a directed graph into a given file
+func (g *DirectedGraph[K]) ExportEdgeList(fname string) error {
+	return g.ExportEdgeListFunc(fname, DefaultLabel[K])
+}
+
+// like ExportEdgeList, but node IDs are rendered by label
+func (g *DirectedGraph[K]) ExportEdgeListFunc(fname string, label func(K) string) error {
+	return exportFile(fname, func(w io.Writer) error {
+		return g.WriteEdgeListFunc(w, label)
+	})
+}
+
+// function to write the edge list of an undirected graph to a writer.
+// each edge is written once, the way Edges reports it
+func (g *UndirectedGraph[K]) WriteEdgeList(w io.Writer) error {
+	return g.WriteEdgeListFunc(w, DefaultLabel[K])
+}
+
+// like WriteEdgeList, but node IDs are rendered by label. a nil label
+// function falls back to DefaultLabel
+func (g *UndirectedGraph[K]) WriteEdgeListFunc(w io.Writer, label func(K) string) error {
+	return writeEdgeList(w, g.Edges(), label)
+}
+
+// function to export the edge list of an undirected graph into a given file
+func (g *UndirectedGraph[K]) ExportEdgeList(fname string) error {
+	return g.ExportEdgeListFunc(fname, DefaultLabel[K])
+}
+
+// like ExportEdgeList, but node IDs are rendered by label
+func (g *UndirectedGraph[K]) ExportEdgeListFunc(fname string, label func(K) string) error {
+	return exportFile(fname, func(w io.Writer) error {
+		return g.WriteEdgeListFunc(w, label)
+	})
+}
+
+// helper to write a graph in the DOT language. keyword is either graph
+// or digraph, and op the matching edge operator. nodes and edges are
+// sorted by ID, edge weights become weight attributes, and node IDs are
+// rendered by label, or DefaultLabel if it's nil
+func (g *graphData[K]) writeDOT(w io.Writer, keyword, op string, edges []Edge[K], label func(K) string) error {
+	if label == nil {
+		label = DefaultLabel[K]
+	}
+	writer := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(writer, "%s {\n", keyword); err != nil {
+		return err
+	}
+	// list all nodes so that isolated ones show up too
+	for _, n := range sortedNodes(g.Nodes()) {
+		if _, err := fmt.Fprintf(writer, "\t%s;\n", strconv.Quote(label(n.ID))); err != nil {
+			return err
+		}
+	}
+	for _, e := range sortedEdges(edges) {
+		u, v := strconv.Quote(label(e.u.ID)), strconv.Quote(label(e.v.ID))
+		weight := strconv.FormatFloat(e.weight, 'g', -1, 64)
+		if _, err := fmt.Fprintf(writer, "\t%s %s %s [weight=%s];\n", u, op, v, weight); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(writer, "}"); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// function to write a directed graph in the DOT language used by Graphviz
+func (g *DirectedGraph[K]) WriteDOT(w io.Writer) error {
+	return g.WriteDOTFunc(w, DefaultLabel[K])
+}
+
+// like WriteDOT, but node IDs are rendered by label
+func (g *DirectedGraph[K]) WriteDOTFunc(w io.Writer, label func(K) string) error {
+	return g.writeDOT(w, "digraph", "->", g.Edges(), label)
+}
+
+// function to export a directed graph into a DOT file
+func (g *DirectedGraph[K]) ExportDOT(fname string) error {
+	return g.ExportDOTFunc(fname, DefaultLabel[K])
+}
+
+// like ExportDOT, but node IDs are rendered by label
+func (g *DirectedGraph[K]) ExportDOTFunc(fname string, label func(K) string) error {
+	return exportFile(fname, func(w io.Writer) error {
+		return g.WriteDOTFunc(w, label)
+	})
+}
+
+// function to write an undirected graph in the DOT language used by Graphviz
+func (g *UndirectedGraph[K]) WriteDOT(w io.Writer) error {
+	return g.WriteDOTFunc(w, DefaultLabel[K])
+}
+
+// like WriteDOT, but node IDs are rendered by label
+func (g *UndirectedGraph[K]) WriteDOTFunc(w io.Writer, label func(K) string) error {
+	return g.writeDOT(w, "graph", "--", g.Edges(), label)
+}
+
+// function to export an undirected graph into a DOT file
+func (g *UndirectedGraph[K]) ExportDOT(fname string) error {
+	return g.ExportDOTFunc(fname, DefaultLabel[K])
+}
+
+// like ExportDOT, but node IDs are rendered by label
+func (g *UndirectedGraph[K]) ExportDOTFunc(fname string, label func(K) string) error {
+	return exportFile(fname, func(w io.Writer) error {
+		return g.WriteDOTFunc(w, label)
+	})
+}
+
+// helper to write a graph as a Mermaid flowchart. node IDs can contain
+// anything, so nodes are declared under generated aliases n0, n1, ...
+// in ID order, with the ID as their label. op is the arrow between the
+// aliases, and edge weights become edge labels
+func (g *graphData[K]) writeMermaid(w io.Writer, op string, edges []Edge[K]) error {
+	writer := bufio.NewWriter(w)
+	if _, err := fmt.Fprintln(writer, "flowchart TD"); err != nil {
+		return err
+	}
+	aliases := make(map[Node[K]]string, len(g.Adjacencies))
+	for i, n := range sortedNodes(g.Nodes()) {
+		aliases[n] = fmt.Sprintf("n%d", i)
+		// quotes would end the label, Mermaid wants them as an entity
+		label := strings.ReplaceAll(DefaultLabel(n.ID), `"`, "#quot;")
+		if _, err := fmt.Fprintf(writer, "\t%s[\"%s\"]\n", aliases[n], label); err != nil {
+			return err
+		}
+	}
+	for _, e := range sortedEdges(edges) {
+		weight := strconv.FormatFloat(e.weight, 'g', -1, 64)
+		if _, err := fmt.Fprintf(writer, "\t%s %s|%s| %s\n", aliases[e.u], op, weight, aliases[e.v]); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// function to write a directed graph as a Mermaid flowchart, which
+// renders in Markdown on GitHub
+func (g *DirectedGraph[K]) WriteMermaid(w io.Writer) error {
+	return g.writeMermaid(w, "-->", g.Edges())
+}
+
+// function to export a directed graph into a Mermaid file
+func (g *DirectedGraph[K]) ExportMermaid(fname string) error {
+	return exportFile(fname, g.WriteMermaid)
+}
+
+// function to write an undirected graph as a Mermaid flowchart, with
+// plain lines instead of arrows
+func (g *UndirectedGraph[K]) WriteMermaid(w io.Writer) error {
+	return g.writeMermaid(w, "---", g.Edges())
+}
+
+// function to export an undirected graph into a Mermaid file
+func (g *UndirectedGraph[K]) ExportMermaid(fname string) error {
+	return exportFile(fname, g.WriteMermaid)
+}
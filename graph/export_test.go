@@ -0,0 +1,229 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writer that fails after accepting a number of bytes
+type failingWriter struct {
+	remaining int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > f.remaining {
+		n := f.remaining
+		f.remaining = 0
+		return n, errors.New("disk full")
+	}
+	f.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestEdgeList(t *testing.T) {
+	g := NewDirectedGraph[string]()
+	g.AddEdge(Node[string]{"a"}, Node[string]{"b"}, 1.0)
+
+	t.Run("Write edge list to a buffer", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.WriteEdgeList(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if buf.String() != "'a' 'b'\n" {
+			t.Errorf("Expected single edge line, got %q", buf.String())
+		}
+	})
+
+	t.Run("Write undirected edges once", func(t *testing.T) {
+		u := NewUndirectedGraph[int]()
+		u.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		u.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		var buf bytes.Buffer
+		if err := u.WriteEdgeList(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		slices.Sort(lines)
+		expected := []string{"'1' '2'", "'2' '3'"}
+		if !slices.Equal(lines, expected) {
+			t.Errorf("Expected %v, got %v", expected, lines)
+		}
+	})
+
+	t.Run("Write errors are returned", func(t *testing.T) {
+		// enough edges to overflow the internal buffer several times
+		h := NewDirectedGraph[int]()
+		for i := range 10000 {
+			h.AddEdge(Node[int]{i}, Node[int]{i + 1}, 1.0)
+		}
+		if err := h.WriteEdgeList(&failingWriter{remaining: 100}); err == nil {
+			t.Errorf("Expected write error to be returned")
+		}
+	})
+
+	t.Run("Export edge list to a file", func(t *testing.T) {
+		fname := filepath.Join(t.TempDir(), "edges.txt")
+		if err := g.ExportEdgeList(fname); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		buf, _ := os.ReadFile(fname)
+		if strings.TrimSpace(string(buf)) != "'a' 'b'" {
+			t.Errorf("Expected single edge line, got %q", string(buf))
+		}
+	})
+}
+
+func TestDOT(t *testing.T) {
+	t.Run("Write directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[string]()
+		g.AddEdge(Node[string]{"b"}, Node[string]{"a"}, 2.5)
+		g.AddNode(Node[string]{"c"})
+
+		var buf bytes.Buffer
+		if err := g.WriteDOT(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "digraph {\n\t\"a\";\n\t\"b\";\n\t\"c\";\n\t\"b\" -> \"a\" [weight=2.5];\n}\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Write undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+
+		var buf bytes.Buffer
+		if err := g.WriteDOT(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "graph {\n\t\"1\";\n\t\"2\";\n\t\"1\" -- \"2\" [weight=1];\n}\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Export DOT file", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+
+		fname := filepath.Join(t.TempDir(), "graph.dot")
+		if err := g.ExportDOT(fname); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		buf, _ := os.ReadFile(fname)
+		if !strings.HasPrefix(string(buf), "graph {") {
+			t.Errorf("Expected DOT graph in file, got %q", string(buf))
+		}
+	})
+}
+
+func TestExportLabels(t *testing.T) {
+	type point struct{ x, y int }
+	label := func(p point) string {
+		return fmt.Sprintf("%d/%d", p.x, p.y)
+	}
+	g := NewDirectedGraph[point]()
+	g.AddEdge(Node[point]{point{0, 0}}, Node[point]{point{1, 2}}, 1.0)
+
+	t.Run("Default label", func(t *testing.T) {
+		if l := DefaultLabel(point{1, 2}); l != "{1 2}" {
+			t.Errorf("Expected {1 2}, got %q", l)
+		}
+	})
+
+	t.Run("Edge list with labels", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.WriteEdgeListFunc(&buf, label); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if buf.String() != "'0/0' '1/2'\n" {
+			t.Errorf("Expected labelled edge line, got %q", buf.String())
+		}
+	})
+
+	t.Run("DOT with labels", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := g.WriteDOTFunc(&buf, label); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "digraph {\n\t\"0/0\";\n\t\"1/2\";\n\t\"0/0\" -> \"1/2\" [weight=1];\n}\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Nil label falls back to the default", func(t *testing.T) {
+		var withNil, withDefault bytes.Buffer
+		g.WriteDOTFunc(&withNil, nil)
+		g.WriteDOT(&withDefault)
+		if withNil.String() != withDefault.String() {
+			t.Errorf("Expected %q, got %q", withDefault.String(), withNil.String())
+		}
+	})
+
+	t.Run("Export files with labels", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := g.ExportEdgeListFunc(filepath.Join(dir, "edges.txt"), label); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := g.ExportDOTFunc(filepath.Join(dir, "graph.dot"), label); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		buf, _ := os.ReadFile(filepath.Join(dir, "graph.dot"))
+		if !strings.Contains(string(buf), "\"0/0\" -> \"1/2\"") {
+			t.Errorf("Expected labelled edge in file, got %q", string(buf))
+		}
+	})
+}
+
+func TestMermaid(t *testing.T) {
+	t.Run("Write directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[string]()
+		g.AddEdge(Node[string]{"b"}, Node[string]{`a "x"`}, 2.5)
+		g.AddNode(Node[string]{"c d"})
+
+		var buf bytes.Buffer
+		if err := g.WriteMermaid(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "flowchart TD\n\tn0[\"a #quot;x#quot;\"]\n\tn1[\"b\"]\n\tn2[\"c d\"]\n\tn1 -->|2.5| n0\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Write undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+
+		var buf bytes.Buffer
+		if err := g.WriteMermaid(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "flowchart TD\n\tn0[\"1\"]\n\tn1[\"2\"]\n\tn0 ---|1| n1\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Export Mermaid file", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+
+		fname := filepath.Join(t.TempDir(), "graph.mmd")
+		if err := g.ExportMermaid(fname); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		buf, _ := os.ReadFile(fname)
+		if !strings.HasPrefix(string(buf), "flowchart TD") {
+			t.Errorf("Expected Mermaid flowchart in file, got %q", string(buf))
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package graph
+
+import "math"
+
+// residual capacities left on each edge while pushing flow
+type residual[K comparable] map[Node[K]]map[Node[K]]float64
+
+// helper to run Edmonds-Karp from source to sink. edge weights are the
+// capacities. returns the flow value and the residual capacities
+func (g *DirectedGraph[K]) maxFlow(source, sink Node[K]) (float64, residual[K]) {
+	// the residual graph starts with the full capacity of each edge
+	// and room to push flow back along every edge
+	res := make(residual[K])
+	for u := range g.NodesSeq() {
+		res[u] = make(map[Node[K]]float64)
+	}
+	for u := range g.NodesSeq() {
+		for v, w := range g.successorsSeq(u) {
+			res[u][v] += w
+			if _, ok := res[v][u]; !ok {
+				res[v][u] = 0.0
+			}
+		}
+	}
+
+	flow := 0.0
+	if source == sink || !g.HasNode(source) || !g.HasNode(sink) {
+		return flow, res
+	}
+
+	for {
+		// find the shortest augmenting path with a BFS
+		previous := map[Node[K]]Node[K]{source: source}
+		queue := Queue[K]{source}
+		for len(queue) > 0 && !hasKey(previous, sink) {
+			current := queue[0]
+			queue = queue[1:]
+			for neighbor, capacity := range res[current] {
+				if capacity > 0 && !hasKey(previous, neighbor) {
+					previous[neighbor] = current
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+		// no path left, the flow is maximal
+		if !hasKey(previous, sink) {
+			break
+		}
+
+		// find the bottleneck along the path
+		bottleneck := math.Inf(1)
+		for v := sink; v != source; v = previous[v] {
+			bottleneck = math.Min(bottleneck, res[previous[v]][v])
+		}
+		// and push that much flow along it
+		for v := sink; v != source; v = previous[v] {
+			u := previous[v]
+			res[u][v] -= bottleneck
+			res[v][u] += bottleneck
+		}
+		flow += bottleneck
+	}
+
+	return flow, res
+}
+
+// helper to check whether a map has a key
+func hasKey[K comparable, V any](m map[K]V, k K) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// calculate the maximum flow from source to sink, using edge weights
+// as capacities
+func (g *DirectedGraph[K]) MaxFlow(source, sink Node[K]) float64 {
+	flow, _ := g.maxFlow(source, sink)
+	return flow
+}
+
+// calculate a minimum cut between source and sink. returns the nodes on
+// the source side, the nodes on the sink side, and the total capacity of
+// the edges crossing from one to the other, which equals the max flow
+func (g *DirectedGraph[K]) MinCut(source, sink Node[K]) ([]Node[K], []Node[K], float64) {
+	flow, res := g.maxFlow(source, sink)
+
+	// the source side is everything still reachable in the residual graph
+	reachable := map[Node[K]]bool{source: true}
+	queue := Queue[K]{source}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for neighbor, capacity := range res[current] {
+			if capacity > 0 && !reachable[neighbor] {
+				reachable[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	sourceSide := make([]Node[K], 0)
+	sinkSide := make([]Node[K], 0)
+	for n := range g.NodesSeq() {
+		if reachable[n] {
+			sourceSide = append(sourceSide, n)
+		} else {
+			sinkSide = append(sinkSide, n)
+		}
+	}
+	return sourceSide, sinkSide, flow
+}
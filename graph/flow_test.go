@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMaxFlowMinCut(t *testing.T) {
+	g := NewDirectedGraph[string]()
+	s := Node[string]{"s"}
+	a := Node[string]{"a"}
+	b := Node[string]{"b"}
+	c := Node[string]{"c"}
+	d := Node[string]{"d"}
+	sink := Node[string]{"t"}
+
+	// the classic CLRS flow network, max flow 23
+	g.AddEdge(s, a, 16.0)
+	g.AddEdge(s, c, 13.0)
+	g.AddEdge(c, a, 4.0)
+	g.AddEdge(a, b, 12.0)
+	g.AddEdge(b, c, 9.0)
+	g.AddEdge(c, d, 14.0)
+	g.AddEdge(d, b, 7.0)
+	g.AddEdge(b, sink, 20.0)
+	g.AddEdge(d, sink, 4.0)
+
+	flow := g.MaxFlow(s, sink)
+	sourceSide, sinkSide, cut := g.MinCut(s, sink)
+
+	t.Run("Max flow value", func(t *testing.T) {
+		if flow != 23.0 {
+			t.Errorf("Expected max flow of 23.0, got %f", flow)
+		}
+	})
+
+	t.Run("Cut value equals max flow", func(t *testing.T) {
+		if cut != flow {
+			t.Errorf("Expected cut value %f to equal max flow %f", cut, flow)
+		}
+		// add up the capacity crossing the cut to be sure
+		crossing := 0.0
+		for _, u := range sourceSide {
+			for v, w := range g.successorsSeq(u) {
+				if slices.Contains(sinkSide, v) {
+					crossing += w
+				}
+			}
+		}
+		if crossing != cut {
+			t.Errorf("Expected crossing capacity %f to equal cut value %f", crossing, cut)
+		}
+	})
+
+	t.Run("Cut partitions the nodes", func(t *testing.T) {
+		if len(sourceSide)+len(sinkSide) != 6 {
+			t.Errorf("Expected 6 nodes across both sides, got %v and %v", sourceSide, sinkSide)
+		}
+		if !slices.Contains(sourceSide, s) || !slices.Contains(sinkSide, sink) {
+			t.Errorf("Expected source and sink on opposite sides, got %v and %v", sourceSide, sinkSide)
+		}
+	})
+
+	t.Run("Disconnected sink", func(t *testing.T) {
+		g.AddNode(Node[string]{"x"})
+		if f := g.MaxFlow(s, Node[string]{"x"}); f != 0.0 {
+			t.Errorf("Expected no flow to unreachable node, got %f", f)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// function to create a complete graph, with an edge of weight 1.0
+// between every pair of distinct IDs. n IDs give n(n-1)/2 edges
+func CompleteGraph[K comparable](ids []K) *UndirectedGraph[K] {
+	g := NewUndirectedGraph[K]()
+	for i, u := range ids {
+		g.AddNode(Node[K]{ID: u})
+		for _, v := range ids[i+1:] {
+			g.AddEdge(Node[K]{ID: u}, Node[K]{ID: v}, 1.0)
+		}
+	}
+	return g
+}
+
+// function to create a path graph, connecting the IDs in order with
+// edges of weight 1.0. n IDs give n-1 edges
+func PathGraph[K comparable](ids []K) *UndirectedGraph[K] {
+	g := NewUndirectedGraph[K]()
+	for i, id := range ids {
+		g.AddNode(Node[K]{ID: id})
+		if i > 0 {
+			g.AddEdge(Node[K]{ID: ids[i-1]}, Node[K]{ID: id}, 1.0)
+		}
+	}
+	return g
+}
+
+// function to create a cycle graph, like a path graph with an extra
+// edge from the last ID back to the first. n IDs give n edges for n
+// of at least 3. for fewer IDs that would duplicate an edge or make a
+// self-loop, so the result is just the path
+func CycleGraph[K comparable](ids []K) *UndirectedGraph[K] {
+	g := PathGraph(ids)
+	if len(ids) >= 3 {
+		g.AddEdge(Node[K]{ID: ids[len(ids)-1]}, Node[K]{ID: ids[0]}, 1.0)
+	}
+	return g
+}
+
+// function to create a star graph, with the first ID as the center
+// and an edge of weight 1.0 to every other ID. n IDs give n-1 edges
+func StarGraph[K comparable](ids []K) *UndirectedGraph[K] {
+	g := NewUndirectedGraph[K]()
+	if len(ids) == 0 {
+		return g
+	}
+	center := Node[K]{ID: ids[0]}
+	g.AddNode(center)
+	for _, id := range ids[1:] {
+		g.AddEdge(center, Node[K]{ID: id}, 1.0)
+	}
+	return g
+}
+
+// function to create a width by height grid graph. every coordinate is
+// a node, connected to its cardinal neighbors with edges of weight 1.0.
+// that gives (width-1)*height + width*(height-1) edges
+func GridGraph(width, height int) *UndirectedGraph[Coordinate] {
+	g := NewUndirectedGraph[Coordinate]()
+	for y := range height {
+		for x := range width {
+			u := Node[Coordinate]{ID: Coordinate{x, y}}
+			g.AddNode(u)
+			if x+1 < width {
+				g.AddEdge(u, Node[Coordinate]{ID: Coordinate{x + 1, y}}, 1.0)
+			}
+			if y+1 < height {
+				g.AddEdge(u, Node[Coordinate]{ID: Coordinate{x, y + 1}}, 1.0)
+			}
+		}
+	}
+	return g
+}
+
+// helper to pick the weight of a generated edge. a nil weight
+// function means every edge weighs 1.0
+func sampleWeight(rng *rand.Rand, weight func(*rand.Rand) float64) float64 {
+	if weight == nil {
+		return 1.0
+	}
+	return weight(rng)
+}
+
+// function to create an Erdős–Rényi random graph over the nodes 0 to
+// n-1, where every pair of distinct nodes is connected with probability
+// p. edge weights come from weight, or are 1.0 if it's nil. all the
+// randomness comes from rng, so a seeded source gives the same graph
+// every time
+func ErdosRenyiGraph(n int, p float64, rng *rand.Rand, weight func(*rand.Rand) float64) *UndirectedGraph[int] {
+	g := NewUndirectedGraph[int]()
+	for u := range n {
+		g.AddNode(Node[int]{ID: u})
+		for v := u + 1; v < n; v++ {
+			if rng.Float64() < p {
+				g.AddEdge(Node[int]{ID: u}, Node[int]{ID: v}, sampleWeight(rng, weight))
+			}
+		}
+	}
+	return g
+}
+
+// function to create a scale-free Barabási–Albert graph over the nodes
+// 0 to n-1. it starts with m nodes and no edges, then every further node
+// is attached to m distinct existing nodes, picked with a probability
+// proportional to their degree. edge weights come from weight, or are
+// 1.0 if it's nil. all the randomness comes from rng, so a seeded source
+// gives the same graph every time. m has to be at least 1 and less than n
+func BarabasiAlbertGraph(n, m int, rng *rand.Rand, weight func(*rand.Rand) float64) (*UndirectedGraph[int], error) {
+	if m < 1 || m >= n {
+		return nil, fmt.Errorf("need 1 <= m < n, got m=%d and n=%d", m, n)
+	}
+	g := NewUndirectedGraph[int]()
+	// every node shows up here once per edge it has, so picking from it
+	// uniformly picks nodes proportional to their degree
+	repeated := make([]int, 0, 2*m*(n-m))
+	// the first new node attaches to all of the initial nodes
+	targets := make([]int, 0, m)
+	for u := range m {
+		g.AddNode(Node[int]{ID: u})
+		targets = append(targets, u)
+	}
+
+	for u := m; u < n; u++ {
+		for _, v := range targets {
+			g.AddEdge(Node[int]{ID: u}, Node[int]{ID: v}, sampleWeight(rng, weight))
+			repeated = append(repeated, u, v)
+		}
+		// pick the targets for the next node. a slice rather than a set
+		// keeps the order, and with that the weights, deterministic
+		targets = targets[:0]
+		for len(targets) < m {
+			v := repeated[rng.IntN(len(repeated))]
+			if !slices.Contains(targets, v) {
+				targets = append(targets, v)
+			}
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+
+	t.Run("Complete graph", func(t *testing.T) {
+		g := CompleteGraph(ids)
+		if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 10 {
+			t.Errorf("Expected 5 nodes and 10 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if d := g.Density(); d != 1.0 {
+			t.Errorf("Expected density 1.0, got %f", d)
+		}
+	})
+
+	t.Run("Path graph", func(t *testing.T) {
+		g := PathGraph(ids)
+		if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 4 {
+			t.Errorf("Expected 5 nodes and 4 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if g.HasCycle() {
+			t.Errorf("Expected path graph to have no cycle")
+		}
+	})
+
+	t.Run("Cycle graph", func(t *testing.T) {
+		g := CycleGraph(ids)
+		if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 5 {
+			t.Errorf("Expected 5 nodes and 5 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		for _, n := range g.Nodes() {
+			if d := g.Degree(n); d != 2 {
+				t.Errorf("Expected degree 2 for %v, got %d", n, d)
+			}
+		}
+		// too short for a cycle
+		if h := CycleGraph([]int{1, 2}); h.NumberOfEdges() != 1 {
+			t.Errorf("Expected 1 edge, got %d", h.NumberOfEdges())
+		}
+	})
+
+	t.Run("Star graph", func(t *testing.T) {
+		g := StarGraph(ids)
+		if g.NumberOfNodes() != 5 || g.NumberOfEdges() != 4 {
+			t.Errorf("Expected 5 nodes and 4 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if d := g.Degree(Node[int]{1}); d != 4 {
+			t.Errorf("Expected center degree 4, got %d", d)
+		}
+	})
+
+	t.Run("Grid graph", func(t *testing.T) {
+		g := GridGraph(4, 3)
+		if g.NumberOfNodes() != 12 || g.NumberOfEdges() != 17 {
+			t.Errorf("Expected 12 nodes and 17 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		path, length := g.BFS(Node[Coordinate]{Coordinate{0, 0}}, Node[Coordinate]{Coordinate{3, 2}})
+		if length != 6 {
+			t.Errorf("Expected path of 6 nodes, got %v", path)
+		}
+	})
+}
+
+func TestRandomGraphs(t *testing.T) {
+	t.Run("Erdős–Rényi extremes", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		if g := ErdosRenyiGraph(6, 0.0, rng, nil); g.NumberOfNodes() != 6 || g.NumberOfEdges() != 0 {
+			t.Errorf("Expected 6 nodes and no edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if g := ErdosRenyiGraph(6, 1.0, rng, nil); g.NumberOfEdges() != 15 {
+			t.Errorf("Expected a complete graph with 15 edges, got %d", g.NumberOfEdges())
+		}
+	})
+
+	t.Run("Erdős–Rényi is deterministic", func(t *testing.T) {
+		weight := func(rng *rand.Rand) float64 { return float64(1 + rng.IntN(9)) }
+		g := ErdosRenyiGraph(50, 0.2, rand.New(rand.NewPCG(7, 7)), weight)
+		h := ErdosRenyiGraph(50, 0.2, rand.New(rand.NewPCG(7, 7)), weight)
+		if !sameAdjacencies(g.Adjacencies, h.Adjacencies) {
+			t.Errorf("Expected the same seed to give the same graph")
+		}
+	})
+
+	t.Run("Barabási–Albert", func(t *testing.T) {
+		g, err := BarabasiAlbertGraph(100, 3, rand.New(rand.NewPCG(1, 2)), nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// every node after the first 3 adds 3 edges
+		if g.NumberOfNodes() != 100 || g.NumberOfEdges() != 97*3 {
+			t.Errorf("Expected 100 nodes and 291 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if n := g.NumberOfComponents(); n != 1 {
+			t.Errorf("Expected a connected graph, got %d components", n)
+		}
+		h, _ := BarabasiAlbertGraph(100, 3, rand.New(rand.NewPCG(1, 2)), nil)
+		if !sameAdjacencies(g.Adjacencies, h.Adjacencies) {
+			t.Errorf("Expected the same seed to give the same graph")
+		}
+	})
+
+	t.Run("Barabási–Albert with invalid m", func(t *testing.T) {
+		if _, err := BarabasiAlbertGraph(3, 3, rand.New(rand.NewPCG(1, 2)), nil); err == nil {
+			t.Errorf("Expected an error for m >= n")
+		}
+	})
+}
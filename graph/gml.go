@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// helper to write a graph in GML. nodes are numbered in ID order and
+// carry their ID as the label, edge weights become value attributes
+func (g *graphData[K]) writeGML(w io.Writer, directed bool, edges []Edge[K]) error {
+	writer := bufio.NewWriter(w)
+	flag := 0
+	if directed {
+		flag = 1
+	}
+	if _, err := fmt.Fprintf(writer, "graph [\n\tdirected %d\n", flag); err != nil {
+		return err
+	}
+
+	// GML refers to nodes by number, so hand those out first
+	numbers := make(map[Node[K]]int, len(g.Adjacencies))
+	for i, n := range sortedNodes(g.Nodes()) {
+		numbers[n] = i
+		// quotes and ampersands in strings are written as HTML entities
+		label := html.EscapeString(DefaultLabel(n.ID))
+		if _, err := fmt.Fprintf(writer, "\tnode [\n\t\tid %d\n\t\tlabel \"%s\"\n\t]\n", i, label); err != nil {
+			return err
+		}
+	}
+	for _, e := range sortedEdges(edges) {
+		weight := strconv.FormatFloat(e.weight, 'g', -1, 64)
+		if _, err := fmt.Fprintf(writer, "\tedge [\n\t\tsource %d\n\t\ttarget %d\n\t\tvalue %s\n\t]\n", numbers[e.u], numbers[e.v], weight); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(writer, "]"); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// function to write a directed graph in GML
+func (g *DirectedGraph[K]) WriteGML(w io.Writer) error {
+	return g.writeGML(w, true, g.Edges())
+}
+
+// function to export a directed graph into a GML file
+func (g *DirectedGraph[K]) ExportGML(fname string) error {
+	return exportFile(fname, g.WriteGML)
+}
+
+// function to write an undirected graph in GML
+func (g *UndirectedGraph[K]) WriteGML(w io.Writer) error {
+	return g.writeGML(w, false, g.Edges())
+}
+
+// function to export an undirected graph into a GML file
+func (g *UndirectedGraph[K]) ExportGML(fname string) error {
+	return exportFile(fname, g.WriteGML)
+}
+
+// a key and its value in a GML file. the value is either a scalar, or
+// a list of more key-value pairs between brackets
+type gmlPair struct {
+	key    string
+	value  string
+	list   []gmlPair
+	isList bool
+}
+
+// helper to split GML source into tokens, dropping comments. strings
+// come back with their quotes so they can't be mistaken for brackets
+func tokenizeGML(src string) ([]string, error) {
+	tokens := make([]string, 0)
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			// comment, skip to the end of the line
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '[' || r == ']':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"':
+			// strings can't contain quotes, those are written as &quot;
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end + 1
+		default:
+			// keys and numbers run until the next space or bracket
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("[]\"", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+	return tokens, nil
+}
+
+// helper to parse tokens into key-value pairs up to the end of the
+// current list. returns the pairs and the position after the list
+func parseGML(tokens []string, pos int, nested bool) ([]gmlPair, int, error) {
+	pairs := make([]gmlPair, 0)
+	for pos < len(tokens) {
+		key := tokens[pos]
+		if key == "]" {
+			if !nested {
+				return nil, pos, errors.New("unexpected ]")
+			}
+			return pairs, pos + 1, nil
+		}
+		if key == "[" || strings.HasPrefix(key, "\"") {
+			return nil, pos, fmt.Errorf("expected a key, got %s", key)
+		}
+		if pos+1 >= len(tokens) {
+			return nil, pos, fmt.Errorf("missing value for %s", key)
+		}
+
+		value := tokens[pos+1]
+		switch value {
+		case "]":
+			return nil, pos, fmt.Errorf("missing value for %s", key)
+		case "[":
+			list, next, err := parseGML(tokens, pos+2, true)
+			if err != nil {
+				return nil, next, err
+			}
+			pairs = append(pairs, gmlPair{key: key, list: list, isList: true})
+			pos = next
+		default:
+			// strings lose their quotes and have their entities decoded
+			if strings.HasPrefix(value, "\"") {
+				value = html.UnescapeString(value[1 : len(value)-1])
+			}
+			pairs = append(pairs, gmlPair{key: key, value: value})
+			pos += 2
+		}
+	}
+	if nested {
+		return nil, pos, errors.New("unexpected end of file, missing ]")
+	}
+	return pairs, pos, nil
+}
+
+// helper to look up a scalar value in a GML list
+func gmlValue(pairs []gmlPair, key string) (string, bool) {
+	for _, pair := range pairs {
+		if pair.key == key && !pair.isList {
+			return pair.value, true
+		}
+	}
+	return "", false
+}
+
+// function to read a graph from a GML file. only the basic structure is
+// supported:
+//   - a single graph [ ... ] list, directed if it has directed 1
+//   - node [ id ... label ... ] lists. nodes are named by their label,
+//     or by their id if they have none
+//   - edge [ source ... target ... value ... ] lists, where source and
+//     target are node ids. the value is the weight, 1.0 if it's missing
+//   - comments starting with #
+//
+// every other key is skipped, and so are lists other than nodes and
+// edges, like graphics. node ids have to be unique, and so do the
+// names they end up with
+func ImportGML(r io.Reader) (Graph[string], error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenizeGML(string(src))
+	if err != nil {
+		return nil, err
+	}
+	top, _, err := parseGML(tokens, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// find the one graph in the file
+	var body []gmlPair
+	found := false
+	for _, pair := range top {
+		if pair.key != "graph" || !pair.isList {
+			continue
+		}
+		if found {
+			return nil, errors.New("more than one graph in the file")
+		}
+		body, found = pair.list, true
+	}
+	if !found {
+		return nil, errors.New("no graph in the file")
+	}
+
+	var g Graph[string]
+	if directed, _ := gmlValue(body, "directed"); directed == "1" {
+		g = NewDirectedGraph[string]()
+	} else {
+		g = NewUndirectedGraph[string]()
+	}
+
+	// the nodes come first, since edges may be listed before them
+	names := make(map[string]string)
+	taken := make(map[string]bool)
+	for _, pair := range body {
+		if pair.key != "node" || !pair.isList {
+			continue
+		}
+		id, ok := gmlValue(pair.list, "id")
+		if !ok {
+			return nil, errors.New("node without an id")
+		}
+		if _, ok := names[id]; ok {
+			return nil, fmt.Errorf("duplicate node id %s", id)
+		}
+		name, ok := gmlValue(pair.list, "label")
+		if !ok {
+			name = id
+		}
+		if taken[name] {
+			return nil, fmt.Errorf("duplicate node name %q", name)
+		}
+		names[id], taken[name] = name, true
+		g.AddNode(Node[string]{ID: name})
+	}
+
+	for _, pair := range body {
+		if pair.key != "edge" || !pair.isList {
+			continue
+		}
+		ends := make([]Node[string], 0, 2)
+		for _, key := range []string{"source", "target"} {
+			id, ok := gmlValue(pair.list, key)
+			if !ok {
+				return nil, fmt.Errorf("edge without a %s", key)
+			}
+			name, ok := names[id]
+			if !ok {
+				return nil, fmt.Errorf("edge %s refers to unknown node %s", key, id)
+			}
+			ends = append(ends, Node[string]{ID: name})
+		}
+		w := 1.0
+		if value, ok := gmlValue(pair.list, "value"); ok {
+			if w, err = strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("invalid edge value %q", value)
+			}
+		}
+		g.AddEdge(ends[0], ends[1], w)
+	}
+	return g, nil
+}
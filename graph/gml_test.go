@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGML(t *testing.T) {
+	a, b, c := Node[string]{"a"}, Node[string]{"b"}, Node[string]{"c"}
+
+	t.Run("Write directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[string]()
+		g.AddEdge(b, a, 2.5)
+
+		var buf bytes.Buffer
+		if err := g.WriteGML(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "graph [\n\tdirected 1\n" +
+			"\tnode [\n\t\tid 0\n\t\tlabel \"a\"\n\t]\n" +
+			"\tnode [\n\t\tid 1\n\t\tlabel \"b\"\n\t]\n" +
+			"\tedge [\n\t\tsource 1\n\t\ttarget 0\n\t\tvalue 2.5\n\t]\n]\n"
+		if buf.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Read basic structure", func(t *testing.T) {
+		src := `Creator "someone"
+		graph [
+			# nodes may come after the edges that use them
+			directed 1
+			edge [ source 1 target 2 value 2.5 ]
+			edge [ source 2 target 3 ]
+			node [ id 1 label "a" graphics [ x 1.0 y 2.0 ] ]
+			node [ id 2 label "b" ]
+			node [ id 3 ]
+		]`
+		g, err := ImportGML(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := g.(*DirectedGraph[string]); !ok {
+			t.Fatalf("Expected a directed graph, got %T", g)
+		}
+		if weight, ok := g.EdgeWeight(a, b); !ok || weight != 2.5 {
+			t.Errorf("Expected a->b with weight 2.5, got %f and %t", weight, ok)
+		}
+		// nodes without a label are named by their id
+		if weight, ok := g.EdgeWeight(b, Node[string]{"3"}); !ok || weight != 1.0 {
+			t.Errorf("Expected b->3 with default weight 1.0, got %f and %t", weight, ok)
+		}
+		if g.NumberOfNodes() != 3 || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected 3 nodes and 2 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+	})
+
+	t.Run("Undirected by default", func(t *testing.T) {
+		g, err := ImportGML(strings.NewReader("graph [ node [ id 0 ] node [ id 1 ] edge [ source 0 target 1 ] ]"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := g.(*UndirectedGraph[string]); !ok {
+			t.Fatalf("Expected an undirected graph, got %T", g)
+		}
+		if !g.HasEdge(Node[string]{"1"}, Node[string]{"0"}) {
+			t.Errorf("Expected edge 0-1, got %v", g.Edges())
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		g := NewUndirectedGraph[string]()
+		g.AddEdge(a, b, 2.0)
+		g.AddEdge(b, Node[string]{`say "hi" & bye`}, 0.5)
+		g.AddNode(c)
+
+		fname := filepath.Join(t.TempDir(), "graph.gml")
+		if err := g.ExportGML(fname); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		f, _ := os.Open(fname)
+		defer f.Close()
+		h, err := ImportGML(f)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, h.Copy().Adjacencies) {
+			t.Errorf("Expected %v, got %v", g.Adjacencies, h.Copy().Adjacencies)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		cases := map[string]string{
+			"no graph":            "node [ id 1 ]",
+			"two graphs":          "graph [ ] graph [ ]",
+			"unclosed list":       "graph [ node [ id 1 ]",
+			"stray bracket":       "graph [ ] ]",
+			"missing value":       "graph [ directed ]",
+			"unterminated string": "graph [ node [ id 1 label \"a ] ]",
+			"node without id":     "graph [ node [ label \"a\" ] ]",
+			"duplicate id":        "graph [ node [ id 1 ] node [ id 1 ] ]",
+			"duplicate name":      "graph [ node [ id 1 label \"a\" ] node [ id 2 label \"a\" ] ]",
+			"unknown node":        "graph [ node [ id 1 ] edge [ source 1 target 2 ] ]",
+			"edge without target": "graph [ node [ id 1 ] edge [ source 1 ] ]",
+			"invalid value":       "graph [ node [ id 1 ] edge [ source 1 target 1 value x ] ]",
+		}
+		for name, src := range cases {
+			if _, err := ImportGML(strings.NewReader(src)); err == nil {
+				t.Errorf("Expected error for %s", name)
+			}
+		}
+	})
+}
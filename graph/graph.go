@@ -1,18 +1,41 @@
 package graph
 
 import (
-	"bufio"
+	"cmp"
 	"fmt"
+	"iter"
 	"maps"
-	"os"
+	"reflect"
 	"slices"
+	"strings"
 )
 
-// nodes can be identified by anything that can be used as a key in a map
+// nodes can be identified by anything that can be used as a key in a map.
+// the graphs key their tables by ID, so there is never more than one
+// node per ID
 type Node[K comparable] struct {
 	ID K
 }
 
+// helper to put node IDs in a stable order. IDs of a numeric or string
+// kind compare by value, everything else compares by its printed form
+func compareIDs[K comparable](a, b K) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(va.Float(), vb.Float())
+		case reflect.String:
+			return strings.Compare(va.String(), vb.String())
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
 // edges are identified by the nodes they connect,
 // and the weight of the connection
 type Edge[K comparable] struct {
@@ -20,6 +43,24 @@ type Edge[K comparable] struct {
 	weight float64
 }
 
+// function to create a new edge between two nodes with a weight
+func NewEdge[K comparable](u, v Node[K], w float64) Edge[K] {
+	return Edge[K]{u: u, v: v, weight: w}
+}
+
+// accessors for the end points and weight of an edge
+func (e Edge[K]) U() Node[K] {
+	return e.u
+}
+
+func (e Edge[K]) V() Node[K] {
+	return e.v
+}
+
+func (e Edge[K]) Weight() float64 {
+	return e.weight
+}
+
 // an adjacency is defined by the other end point and
 // the edge weight
 type Adjancency[K comparable] struct {
@@ -27,6 +68,15 @@ type Adjancency[K comparable] struct {
 	weight float64
 }
 
+// accessors for the other end point and the weight of an adjacency
+func (a Adjancency[K]) V() Node[K] {
+	return a.v
+}
+
+func (a Adjancency[K]) Weight() float64 {
+	return a.weight
+}
+
 // define an interface for an abstract graph
 type Graph[K comparable] interface {
 	AddNode(n Node[K])
@@ -35,12 +85,16 @@ type Graph[K comparable] interface {
 	AddEdgesFrom(es []Edge[K])
 	HasNode(n Node[K]) bool
 	HasEdge(u, v Node[K]) bool
+	EdgeWeight(u, v Node[K]) (float64, bool)
+	SetEdgeWeight(u, v Node[K], w float64) bool
 	RemoveNode(n Node[K])
 	RemoveEdge(u, v Node[K])
 	RemoveNodesFrom(ns []Node[K])
 	RemoveEdgesFrom(es []Edge[K])
 	Nodes() []Node[K]
 	Edges() []Edge[K]
+	NodesSeq() iter.Seq[Node[K]]
+	EdgesSeq() iter.Seq[Edge[K]]
 	Clear()
 	NumberOfNodes() int
 	NumberOfEdges() int
@@ -51,14 +105,37 @@ type Graph[K comparable] interface {
 	InDegree(n Node[K]) int
 	OutDegree(n Node[K]) int
 	Degree(n Node[K]) int
-	Copy() graphData[K]
+	Copy() *graphData[K]
 }
 
+// make sure both graph types satisfy the interface
+var (
+	_ Graph[int] = (*DirectedGraph[int])(nil)
+	_ Graph[int] = (*UndirectedGraph[int])(nil)
+)
+
 // generic data structure for a graph. it's a simple lookup
 // table for graphs and list of graphs with the weight associated
-// with the edge between the two keys
+// with the edge between the two keys. the tables are keyed by node
+// ID rather than by Node, the methods wrap and unwrap IDs at the
+// boundary, so Node could grow more fields. predecessors is the same
+// table the other way around, so incoming edges can be looked up
+// without scanning the whole graph. the methods keep both in sync,
+// so edges should be added and removed through them rather than by
+// writing to Adjacencies directly. degreeHint is how many edges
+// to make room for when a node is added, see Reserve. noSelfLoops
+// makes AddEdge ignore self-loops, see DisallowSelfLoops
 type graphData[K comparable] struct {
-	Adjacencies map[Node[K]]map[Node[K]]float64
+	Adjacencies  map[K]map[K]float64
+	predecessors map[K]map[K]float64
+	degreeHint   int
+	noSelfLoops  bool
+	// node metadata by ID, see SetNodeAttribute
+	attributes map[K]map[string]any
+	// changes to undo while there are snapshots, see Snapshot
+	log *changeLog
+	// callbacks for changes, see OnAddNode
+	observers *observers[K]
 }
 
 // function to wrap a new node
@@ -69,12 +146,75 @@ func (g *graphData[K]) NewNode(obj K) Node[K] {
 // function to add a node to the graph
 func (g *graphData[K]) AddNode(n Node[K]) {
 	// does the node already exist in the graph?
-	if _, ok := g.Adjacencies[n]; !ok {
+	if _, ok := g.Adjacencies[n.ID]; !ok {
 		// no, add it with no adjacencies
-		g.Adjacencies[n] = make(map[Node[K]]float64)
+		g.Adjacencies[n.ID] = make(map[K]float64, g.degreeHint)
+		g.predecessors[n.ID] = make(map[K]float64, g.degreeHint)
+		g.record(func() {
+			delete(g.Adjacencies, n.ID)
+			delete(g.predecessors, n.ID)
+		})
+		g.notifyAddNode(n)
+	}
+}
+
+// function to make room for a number of nodes and edges up front, so
+// that adding them doesn't keep growing the maps. the node tables are
+// grown once, and nodes added from now on get room for their share of
+// the edges. existing nodes and edges are kept
+func (g *graphData[K]) Reserve(nodes, edges int) {
+	if nodes > len(g.Adjacencies) {
+		adjacencies := make(map[K]map[K]float64, nodes)
+		predecessors := make(map[K]map[K]float64, nodes)
+		maps.Copy(adjacencies, g.Adjacencies)
+		maps.Copy(predecessors, g.predecessors)
+		g.Adjacencies, g.predecessors = adjacencies, predecessors
+	}
+	if nodes > 0 {
+		hint := g.degreeHint
+		g.record(func() { g.degreeHint = hint })
+		// round up so that every node has room for its average degree
+		g.degreeHint = (edges + nodes - 1) / nodes
 	}
 }
 
+// helpers to iterate over the successors and predecessors of a node
+// along with the weights of the edges, wrapped up as nodes
+func (g *graphData[K]) successorsSeq(n Node[K]) iter.Seq2[Node[K], float64] {
+	return wrapTable(g.Adjacencies[n.ID])
+}
+
+func (g *graphData[K]) predecessorsSeq(n Node[K]) iter.Seq2[Node[K], float64] {
+	return wrapTable(g.predecessors[n.ID])
+}
+
+// helper to iterate over a table of IDs and weights as nodes and weights
+func wrapTable[K comparable](table map[K]float64) iter.Seq2[Node[K], float64] {
+	return func(yield func(Node[K], float64) bool) {
+		for id, w := range table {
+			if !yield(Node[K]{ID: id}, w) {
+				return
+			}
+		}
+	}
+}
+
+// helper to set the edge from u to v in both the adjacencies and
+// the predecessors. both nodes must already be in the graph
+func (g *graphData[K]) setEdge(u, v Node[K], w float64) {
+	g.logEdge(u.ID, v.ID)
+	g.Adjacencies[u.ID][v.ID] = w
+	g.predecessors[v.ID][u.ID] = w
+}
+
+// helper to remove the edge from u to v from both the adjacencies
+// and the predecessors
+func (g *graphData[K]) deleteEdge(u, v Node[K]) {
+	g.logEdge(u.ID, v.ID)
+	delete(g.Adjacencies[u.ID], v.ID)
+	delete(g.predecessors[v.ID], u.ID)
+}
+
 // functions to add nodes to the graph from some iter
 func (g *graphData[K]) AddNodesFrom(ns []Node[K]) {
 	for _, n := range ns {
@@ -84,28 +224,108 @@ func (g *graphData[K]) AddNodesFrom(ns []Node[K]) {
 
 // function to check whether the graph has a node
 func (g *graphData[K]) HasNode(u Node[K]) bool {
-	_, ok := g.Adjacencies[u]
+	_, ok := g.Adjacencies[u.ID]
 	return ok
 }
 
 // function to check whether the grpah has an edge
 func (g *graphData[K]) HasEdge(u, v Node[K]) bool {
-	_, hasU := g.Adjacencies[u]
-	if !hasU {
+	_, ok := g.Adjacencies[u.ID][v.ID]
+	return ok
+}
+
+// function to look up the weight of the edge from u to v, and
+// whether there is such an edge at all
+func (g *graphData[K]) EdgeWeight(u, v Node[K]) (float64, bool) {
+	w, ok := g.Adjacencies[u.ID][v.ID]
+	return w, ok
+}
+
+// function to change the weight of the edge from u to v. returns
+// whether there was such an edge, a missing edge is not added
+func (g *graphData[K]) SetEdgeWeight(u, v Node[K], w float64) bool {
+	if _, ok := g.Adjacencies[u.ID][v.ID]; !ok {
 		return false
 	}
-	_, hasV := g.Adjacencies[u][v]
-	return hasV
+	g.setEdge(u, v, w)
+	return true
+}
+
+// function to change the weight of every edge in place to f of its old
+// weight, e.g. negating them to look for longest paths instead. the
+// edges of undirected graphs are stored both ways, and f is applied to
+// each, so it should only depend on the weight it's given
+func (g *graphData[K]) MapWeights(f func(float64) float64) {
+	for u, table := range g.Adjacencies {
+		for v, w := range table {
+			g.setEdge(Node[K]{ID: u}, Node[K]{ID: v}, f(w))
+		}
+	}
+}
+
+// function to check whether a node has an edge to itself
+func (g *graphData[K]) HasSelfLoop(n Node[K]) bool {
+	_, ok := g.Adjacencies[n.ID][n.ID]
+	return ok
+}
+
+// function to list the nodes with an edge to themselves, sorted by ID
+func (g *graphData[K]) SelfLoops() []Node[K] {
+	loops := make([]Node[K], 0)
+	for n := range g.NodesSeq() {
+		if g.HasSelfLoop(n) {
+			loops = append(loops, n)
+		}
+	}
+	return sortedNodes(loops)
+}
+
+// function to make AddEdge ignore self-loops on this graph from now
+// on, for inputs where a self-loop can only be a mistake. self-loops
+// that are already in the graph stay, see RemoveSelfLoops
+func (g *graphData[K]) DisallowSelfLoops() {
+	disallowed := g.noSelfLoops
+	g.record(func() { g.noSelfLoops = disallowed })
+	g.noSelfLoops = true
+}
+
+// function to remove every edge from a node to itself. the nodes
+// themselves stay in the graph
+func (g *graphData[K]) RemoveSelfLoops() {
+	for n := range g.NodesSeq() {
+		if w, ok := g.Adjacencies[n.ID][n.ID]; ok {
+			g.deleteEdge(n, n)
+			g.notifyRemoveEdge(n, n, w)
+		}
+	}
 }
 
 // function to remove a node from the graph
 func (g *graphData[K]) RemoveNode(n Node[K]) {
-	// remove all adjancencies to the node
-	for node := range g.Adjacencies {
-		delete(g.Adjacencies[node], n)
+	if !g.HasNode(n) {
+		return
 	}
-	// remove adjacencies from the node, and with that its record
-	delete(g.Adjacencies, n)
+	// remove all adjancencies to the node, the predecessors say where
+	for id := range g.predecessors[n.ID] {
+		g.deleteEdge(Node[K]{ID: id}, n)
+	}
+	// and the node from the predecessors of its successors
+	for id := range g.Adjacencies[n.ID] {
+		g.deleteEdge(n, Node[K]{ID: id})
+	}
+	// remove the now empty record of the node, and its attributes
+	attributes := g.attributes[n.ID]
+	g.record(func() {
+		g.Adjacencies[n.ID] = make(map[K]float64)
+		g.predecessors[n.ID] = make(map[K]float64)
+		if attributes != nil {
+			g.attributes[n.ID] = attributes
+		}
+	})
+	delete(g.Adjacencies, n.ID)
+	delete(g.predecessors, n.ID)
+	delete(g.attributes, n.ID)
+	g.notifyRemoveNode(n)
 }
 
 // function to remove ndoes from the graph sourced from some iter
@@ -115,27 +335,113 @@ func (g *graphData[K]) RemoveNodesFrom(ns []Node[K]) {
 	}
 }
 
-// function to retrieve an iterator over the nodes of the graph
+// function to retrieve a list of the nodes of the graph
 func (g *graphData[K]) Nodes() []Node[K] {
-	return slices.Collect(maps.Keys(g.Adjacencies))
+	return slices.Collect(g.NodesSeq())
+}
+
+// function to retrieve an iterator over the nodes of the graph
+func (g *graphData[K]) NodesSeq() iter.Seq[Node[K]] {
+	return wrapIDs(maps.Keys(g.Adjacencies))
+}
+
+// helper to turn a sequence of IDs into a sequence of nodes
+func wrapIDs[K comparable](ids iter.Seq[K]) iter.Seq[Node[K]] {
+	return func(yield func(Node[K]) bool) {
+		for id := range ids {
+			if !yield(Node[K]{ID: id}) {
+				return
+			}
+		}
+	}
+}
+
+// helper to turn a less function on IDs into a comparison for sorting.
+// without one, IDs compare like they do for the exports, see compareIDs
+func compareBy[K comparable](less func(a, b K) bool) func(a, b K) int {
+	if less == nil {
+		return compareIDs[K]
+	}
+	return func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+}
+
+// function to retrieve the nodes sorted by ID, so that output built
+// from them is the same from run to run. less orders the IDs, and may
+// be nil to use the default order of numbers and strings by value and
+// everything else by its printed form
+func (g *graphData[K]) SortedNodes(less func(a, b K) bool) []Node[K] {
+	compare := compareBy(less)
+	nodes := g.Nodes()
+	slices.SortFunc(nodes, func(a, b Node[K]) int {
+		return compare(a.ID, b.ID)
+	})
+	return nodes
+}
+
+// helper to sort edges by their source, then their target
+func sortEdgesBy[K comparable](edges []Edge[K], less func(a, b K) bool) []Edge[K] {
+	compare := compareBy(less)
+	slices.SortFunc(edges, func(a, b Edge[K]) int {
+		return cmp.Or(compare(a.u.ID, b.u.ID), compare(a.v.ID, b.v.ID))
+	})
+	return edges
+}
+
+// like SortedNodes, but for the edges, sorted by source and then target
+func (g *graphData[K]) SortedEdges(less func(a, b K) bool) []Edge[K] {
+	return sortEdgesBy(g.Edges(), less)
 }
 
 // function to retrieve a list of edges from a graph
 func (g *graphData[K]) Edges() []Edge[K] {
-	edges := make([]Edge[K], 0)
-	for u := range g.Adjacencies {
-		// walk the node's adjacencies
-		for v, w := range g.Adjacencies[u] {
-			// create the edge
-			edges = append(edges, Edge[K]{u: u, v: v, weight: w})
+	return slices.AppendSeq(make([]Edge[K], 0), g.EdgesSeq())
+}
+
+// function to retrieve an iterator over the edges of a graph
+func (g *graphData[K]) EdgesSeq() iter.Seq[Edge[K]] {
+	return func(yield func(Edge[K]) bool) {
+		for u := range g.Adjacencies {
+			// walk the node's adjacencies
+			for v, w := range g.Adjacencies[u] {
+				// create the edge
+				if !yield(Edge[K]{u: Node[K]{ID: u}, v: Node[K]{ID: v}, weight: w}) {
+					return
+				}
+			}
 		}
 	}
-	return edges
 }
 
 // function to reset a graph by clearing its edges and nodes
 func (g *graphData[K]) Clear() {
+	// the observers hear about the nodes once they're all gone
+	var removed []Node[K]
+	if g.observers != nil {
+		removed = g.Nodes()
+		defer func() {
+			for _, n := range removed {
+				g.notifyRemoveNode(n)
+			}
+		}()
+	}
+	if g.log != nil {
+		// keep the old tables around to go back to
+		fresh := newGraphData[K]()
+		fresh.degreeHint, fresh.noSelfLoops = g.degreeHint, g.noSelfLoops
+		g.replace(fresh)
+		return
+	}
 	clear(g.Adjacencies)
+	clear(g.predecessors)
+	clear(g.attributes)
 }
 
 // function to return the number of nodes in the graph
@@ -150,38 +456,57 @@ func (g *graphData[K]) NumberOfEdges() int {
 
 // function to return the successors of a node in the graph
 func (g *graphData[K]) Successors(n Node[K]) []Node[K] {
-	return slices.Collect(maps.Keys(g.Adjacencies[n]))
+	return slices.AppendSeq(make([]Node[K], 0, len(g.Adjacencies[n.ID])), wrapIDs(maps.Keys(g.Adjacencies[n.ID])))
 }
 
 // function to return the predecessors of a node in the graph
 func (g *graphData[K]) Predecessors(n Node[K]) []Node[K] {
-	predecessors := make([]Node[K], 0)
-	// walk all nodes
-	for node := range g.Adjacencies {
-		// walk the node's neighbors
-		for neigh := range g.Adjacencies[node] {
-			// is it the node we are looking for?
-			if neigh == n {
-				predecessors = append(predecessors, node)
-			}
-		}
+	return slices.AppendSeq(make([]Node[K], 0, len(g.predecessors[n.ID])), wrapIDs(maps.Keys(g.predecessors[n.ID])))
+}
+
+// helper to turn a table of end points and weights into adjacencies
+func adjacencies[K comparable](table map[K]float64) []Adjancency[K] {
+	result := make([]Adjancency[K], 0, len(table))
+	for v, w := range table {
+		result = append(result, Adjancency[K]{v: Node[K]{ID: v}, weight: w})
 	}
-	return predecessors
+	return result
 }
 
-// functions to return the in-degree, out-degree, and its sum
+// functions like Successors, Predecessors, and Neighbors, but each node
+// comes with the weight of the edge connecting it
+func (g *graphData[K]) WeightedSuccessors(n Node[K]) []Adjancency[K] {
+	return adjacencies(g.Adjacencies[n.ID])
+}
+
+func (g *graphData[K]) WeightedPredecessors(n Node[K]) []Adjancency[K] {
+	return adjacencies(g.predecessors[n.ID])
+}
+
+func (g *graphData[K]) WeightedNeighbors(n Node[K]) []Adjancency[K] {
+	return append(g.WeightedSuccessors(n), g.WeightedPredecessors(n)...)
+}
+
+// functions to return the in-degree, out-degree, and its sum.
+// a self-loop is both an incoming and an outgoing edge, so it adds
+// one to the in-degree, one to the out-degree, and two to the degree
 func (g *graphData[K]) InDegree(n Node[K]) int {
-	return len(g.Predecessors(n))
+	return len(g.predecessors[n.ID])
 }
 
 func (g *graphData[K]) OutDegree(n Node[K]) int {
-	return len(g.Successors(n))
+	return len(g.Adjacencies[n.ID])
 }
 
 func (g *graphData[K]) Degree(n Node[K]) int {
 	return g.InDegree(n) + g.OutDegree(n)
 }
 
+// for directed graphs the degree already counts a self-loop twice
+func (g *graphData[K]) DegreeWithLoops(n Node[K]) int {
+	return g.Degree(n)
+}
+
 // function to return all the neighbors of a node in the graph
 func (g *graphData[K]) Neighbors(n Node[K]) []Node[K] {
 	return append(g.Successors(n), g.Predecessors(n)...)
@@ -189,52 +514,46 @@ func (g *graphData[K]) Neighbors(n Node[K]) []Node[K] {
 
 // function to deep copy a graph
 func (g *graphData[K]) Copy() *graphData[K] {
-	// create new graph
+	// create new graph with the same settings
 	newG := newGraphData[K]()
-	// registry for copied nodes
-	nodesMap := make(map[K]Node[K])
-	// function to either retrieve a copy of a node, or create it
-	getOrCreate := func(id K) Node[K] {
-		if node, ok := nodesMap[id]; ok {
-			return node
-		}
-		newNode := Node[K]{ID: id}
-		nodesMap[id] = newNode
-		return newNode
+	newG.noSelfLoops = g.noSelfLoops
+	// add all nodes first, so that edges always find both end points
+	for n := range g.NodesSeq() {
+		newG.AddNode(n)
+		newG.copyAttributes(g, n, n)
 	}
+	for e := range g.EdgesSeq() {
+		newG.setEdge(e.u, e.v, e.weight)
+	}
+	return &newG
+}
 
-	for n, neighbors := range g.Adjacencies {
-		newNode := getOrCreate(n.ID)
-		if _, ok := newG.Adjacencies[newNode]; !ok {
-			newG.Adjacencies[newNode] = make(map[Node[K]]float64)
+// helper to copy the part of the graph induced by a set of nodes.
+// nodes that aren't in the graph are skipped
+func (g *graphData[K]) subgraph(nodes []Node[K]) graphData[K] {
+	sub := newGraphData[K]()
+	// add the nodes that exist in this graph
+	for _, n := range nodes {
+		if g.HasNode(n) {
+			sub.AddNode(n)
+			sub.copyAttributes(g, n, n)
 		}
-		for nei, weight := range neighbors {
-			newNeighbor := getOrCreate(nei.ID)
-			newG.Adjacencies[newNode][newNeighbor] = weight
+	}
+	// and copy the edges between them
+	for u := range sub.Adjacencies {
+		for v, w := range g.Adjacencies[u] {
+			if _, ok := sub.Adjacencies[v]; ok {
+				sub.setEdge(Node[K]{ID: u}, Node[K]{ID: v}, w)
+			}
 		}
 	}
-	return &newG
+	return sub
 }
 
 // helper to create an empty new graphData structure
 func newGraphData[K comparable]() graphData[K] {
 	return graphData[K]{
-		Adjacencies: make(map[Node[K]]map[Node[K]]float64),
-	}
-}
-
-// function to export the edge list into a given file
-// this can usually be imported by other graphing libraries
-func (g *graphData[K]) ExportEdgeList(fname string) error {
-	f, err := os.Create(fname)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	writer := bufio.NewWriter(f)
-	for _, e := range g.Edges() {
-		fmt.Fprintf(writer, "'%v' '%v'\n", e.u.ID, e.v.ID)
+		Adjacencies:  make(map[K]map[K]float64),
+		predecessors: make(map[K]map[K]float64),
 	}
-	return writer.Flush()
 }
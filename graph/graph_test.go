@@ -65,8 +65,8 @@ func TestUndirectedGraph_AddNodesAndEdges(t *testing.T) {
 
 		// check edge count
 		n = g.NumberOfEdges()
-		if n != 4 {
-			t.Errorf("Expected 4 edges, got %d", n)
+		if n != 2 {
+			t.Errorf("Expected 2 edges, got %d", n)
 		}
 
 		// add a duplicate edge, with a different weight
@@ -74,13 +74,13 @@ func TestUndirectedGraph_AddNodesAndEdges(t *testing.T) {
 		// edge count should still be the same
 		// check edge count
 		n = g.NumberOfEdges()
-		if n != 4 {
-			t.Errorf("Expected 4 edges after adding duplicate, got %d", n)
+		if n != 2 {
+			t.Errorf("Expected 2 edges after adding duplicate, got %d", n)
 		}
 
 		// and the weight should be the new value
-		if g.Adjacencies[u][v] != 20.0 {
-			t.Errorf("Expected new edge weight %f, got %f", 20.0, g.Adjacencies[u][v])
+		if g.Adjacencies[u.ID][v.ID] != 20.0 {
+			t.Errorf("Expected new edge weight %f, got %f", 20.0, g.Adjacencies[u.ID][v.ID])
 		}
 
 		// add a duplicate node
@@ -138,15 +138,15 @@ func TestUndirectedGraph_RemoveNodesAndEdges(t *testing.T) {
 
 		// check edge count
 		n = g.NumberOfEdges()
-		if n != 6 {
-			t.Errorf("Expected 6 edges, got %d", n)
+		if n != 3 {
+			t.Errorf("Expected 3 edges, got %d", n)
 		}
 
 		// remove an edge that doesn't exist, shouldn't affect edge count
 		g.RemoveEdge(z, u)
 		n = g.NumberOfEdges()
-		if n != 6 {
-			t.Errorf("Expected 6 edges, got %d", n)
+		if n != 3 {
+			t.Errorf("Expected 3 edges, got %d", n)
 		}
 
 		// remove edge between w and x
@@ -156,8 +156,8 @@ func TestUndirectedGraph_RemoveNodesAndEdges(t *testing.T) {
 
 		// check edge count
 		n = g.NumberOfEdges()
-		if n != 2 {
-			t.Errorf("Expected 2 edges, got %d", n)
+		if n != 1 {
+			t.Errorf("Expected 1 edge, got %d", n)
 		}
 	})
 }
@@ -263,11 +263,223 @@ func TestUndirectedGraph_Loop(t *testing.T) {
 		// add an edge from a node to itself
 		g.AddEdge(u, u, 1.0)
 		// that should result in one edges, and a degree of 1
-		if len(g.Adjacencies[u]) != 1 {
-			t.Errorf("Self loop for directed graph expected 1 adjancency, got %d", len(g.Adjacencies[u]))
+		if len(g.Adjacencies[u.ID]) != 1 {
+			t.Errorf("Self loop for undirected graph expected 1 adjancency, got %d", len(g.Adjacencies[u.ID]))
 		}
 		if g.Degree(u) != 1 {
-			t.Errorf("Self loop for directed graph degree of 1, got %d", g.Degree(u))
+			t.Errorf("Self loop for undirected graph degree of 1, got %d", g.Degree(u))
+		}
+		if g.InDegree(u) != 1 || g.OutDegree(u) != 1 {
+			t.Errorf("Self loop for undirected graph in- and out-degree of 1, got %d and %d", g.InDegree(u), g.OutDegree(u))
+		}
+		// counting both ends of the loop gives a degree of 2
+		if g.DegreeWithLoops(u) != 2 {
+			t.Errorf("Self loop for undirected graph degree with loops of 2, got %d", g.DegreeWithLoops(u))
+		}
+	})
+}
+
+func TestEdgeWeight(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 2.0)
+
+		if weight, ok := g.EdgeWeight(u, v); !ok || weight != 2.0 {
+			t.Errorf("Expected weight 2 for u->v, got %f and %t", weight, ok)
+		}
+		if _, ok := g.EdgeWeight(v, u); ok {
+			t.Errorf("Expected no edge v->u")
+		}
+
+		if !g.SetEdgeWeight(u, v, 5.0) {
+			t.Errorf("Expected to update u->v")
+		}
+		if weight, _ := g.EdgeWeight(u, v); weight != 5.0 {
+			t.Errorf("Expected weight 5 for u->v, got %f", weight)
+		}
+		if weight := g.predecessors[v.ID][u.ID]; weight != 5.0 {
+			t.Errorf("Expected predecessor weight 5, got %f", weight)
+		}
+
+		// missing edges aren't added
+		if g.SetEdgeWeight(v, u, 1.0) || g.SetEdgeWeight(u, w, 1.0) {
+			t.Errorf("Expected missing edges not to be updated")
+		}
+		if g.HasEdge(v, u) || g.HasNode(w) {
+			t.Errorf("Expected no edge v->u and no node w")
+		}
+	})
+
+	t.Run("Undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 2.0)
+
+		if !g.SetEdgeWeight(v, u, 3.0) {
+			t.Errorf("Expected to update u-v")
+		}
+		for _, pair := range [][2]Node[int]{{u, v}, {v, u}} {
+			if weight, ok := g.EdgeWeight(pair[0], pair[1]); !ok || weight != 3.0 {
+				t.Errorf("Expected weight 3 for %v-%v, got %f and %t", pair[0], pair[1], weight, ok)
+			}
+		}
+
+		if g.SetEdgeWeight(u, w, 1.0) {
+			t.Errorf("Expected missing edge not to be updated")
+		}
+		if _, ok := g.EdgeWeight(u, w); ok {
+			t.Errorf("Expected no edge u-w")
+		}
+	})
+}
+
+func TestSelfLoops(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(v, v, 1.0)
+		g.AddEdge(u, u, 1.0)
+		g.AddEdge(u, v, 1.0)
+		g.AddNode(w)
+
+		if loops := g.SelfLoops(); !slices.Equal(loops, []Node[int]{u, v}) {
+			t.Errorf("Expected self-loops on u and v, got %v", loops)
+		}
+		if !g.HasSelfLoop(u) || g.HasSelfLoop(w) {
+			t.Errorf("Expected a self-loop on u and none on w")
+		}
+		if n := g.NumberOfEdges(); n != 3 {
+			t.Errorf("Expected 3 edges, got %d", n)
+		}
+
+		g.RemoveSelfLoops()
+		if loops := g.SelfLoops(); len(loops) != 0 {
+			t.Errorf("Expected no self-loops after removal, got %v", loops)
+		}
+		if g.NumberOfEdges() != 1 || g.NumberOfNodes() != 3 {
+			t.Errorf("Expected 1 edge and 3 nodes, got %d and %d", g.NumberOfEdges(), g.NumberOfNodes())
+		}
+		if g.Degree(u) != 1 || g.DegreeWithLoops(u) != 1 {
+			t.Errorf("Expected degree 1 for u, got %d and %d", g.Degree(u), g.DegreeWithLoops(u))
+		}
+	})
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, u, 1.0)
+		g.AddEdge(u, v, 1.0)
+
+		g.RemoveSelfLoops()
+		if g.NumberOfEdges() != 1 || g.InDegree(u) != 0 || g.OutDegree(u) != 1 {
+			t.Errorf("Expected only the edge from u to v, got %v", g.Edges())
+		}
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies")
+		}
+	})
+}
+
+func TestDisallowSelfLoops(t *testing.T) {
+	u, v, _, _, _, _ := getNodes()
+
+	// helper to check a graph before and after disallowing self-loops
+	check := func(t *testing.T, g Graph[int], disallow func()) {
+		// allowed by default
+		g.AddEdge(u, u, 1.0)
+		if g.NumberOfEdges() != 1 {
+			t.Fatalf("Expected self-loop to be added by default")
+		}
+		g.RemoveEdge(u, u)
+
+		disallow()
+		g.AddEdge(v, v, 1.0)
+		g.AddEdgesFrom([]Edge[int]{NewEdge(u, u, 1.0), NewEdge(u, v, 1.0)})
+		if g.NumberOfEdges() != 1 || g.HasEdge(u, u) || g.HasEdge(v, v) {
+			t.Errorf("Expected self-loops to be rejected, got %v", g.Edges())
+		}
+		// the setting carries over to copies
+		if !g.Copy().noSelfLoops {
+			t.Errorf("Expected copy to disallow self-loops too")
+		}
+	}
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		check(t, g, g.DisallowSelfLoops)
+	})
+
+	t.Run("Undirected", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		check(t, g, g.DisallowSelfLoops)
+	})
+
+	t.Run("Other graphs are unaffected", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, u, 1.0)
+		if !g.HasSelfLoop(u) {
+			t.Errorf("Expected self-loop on a fresh graph")
+		}
+	})
+}
+
+func TestDirectedGraph_Loop(t *testing.T) {
+	t.Run("Directed graph self loop", func(t *testing.T) {
+		// create a directed graph
+		g := NewDirectedGraph[int]()
+		u, v, _, _, _, _ := getNodes()
+
+		// add an edge from a node to itself, and one to another node
+		g.AddEdge(u, u, 1.0)
+		g.AddEdge(u, v, 1.0)
+		// the loop counts as one incoming and one outgoing edge
+		if g.InDegree(u) != 1 {
+			t.Errorf("Self loop for directed graph in-degree of 1, got %d", g.InDegree(u))
+		}
+		if g.OutDegree(u) != 2 {
+			t.Errorf("Self loop for directed graph out-degree of 2, got %d", g.OutDegree(u))
+		}
+		// and the degree agrees with their sum
+		if g.Degree(u) != 3 || g.DegreeWithLoops(u) != 3 {
+			t.Errorf("Self loop for directed graph degree of 3, got %d and %d", g.Degree(u), g.DegreeWithLoops(u))
+		}
+	})
+}
+
+func TestUndirectedGraph_HasEdge(t *testing.T) {
+	t.Run("Undirected edge queries are unordered", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		u, v, w, _, _, _ := getNodes()
+
+		g.AddEdge(u, v, 1.0)
+		if !g.HasEdge(u, v) || !g.HasEdge(v, u) {
+			t.Errorf("Expected edge between u and v both ways")
+		}
+
+		// remove only one direction of the edge by hand
+		delete(g.Adjacencies[u.ID], v.ID)
+		if !g.HasEdge(u, v) || !g.HasEdge(v, u) {
+			t.Errorf("Expected edge between u and v both ways after half removal")
+		}
+
+		// edges that don't exist either way are still absent
+		if g.HasEdge(u, w) || g.HasEdge(w, u) {
+			t.Errorf("Expected no edge between u and w")
+		}
+	})
+	t.Run("Half removed edges can still be removed", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		u, v, _, _, _, _ := getNodes()
+
+		g.AddEdge(u, v, 1.0)
+		delete(g.Adjacencies[u.ID], v.ID)
+		g.RemoveEdge(u, v)
+		if g.HasEdge(u, v) || g.HasEdge(v, u) {
+			t.Errorf("Expected edge between u and v to be gone")
+		}
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies")
 		}
 	})
 }
@@ -322,8 +534,8 @@ func TestDirectedGraph_AddNodesAndEdges(t *testing.T) {
 		}
 
 		// and the weight should be the new value
-		if g.Adjacencies[u][v] != 20.0 {
-			t.Errorf("Expected new edge weight %f, got %f", 20.0, g.Adjacencies[u][v])
+		if g.Adjacencies[u.ID][v.ID] != 20.0 {
+			t.Errorf("Expected new edge weight %f, got %f", 20.0, g.Adjacencies[u.ID][v.ID])
 		}
 
 		// add a duplicate node
@@ -411,8 +623,8 @@ func TestDirectedGraph_RemoveNodesAndEdges(t *testing.T) {
 			t.Errorf("Expected 0 edges, got %d", n)
 		}
 		// check that u's adjacency list is empty
-		if len(g.Adjacencies[u]) != 0 {
-			t.Errorf("Expected u's adjacency list to be empty, got length %d", len(g.Adjacencies[u]))
+		if len(g.Adjacencies[u.ID]) != 0 {
+			t.Errorf("Expected u's adjacency list to be empty, got length %d", len(g.Adjacencies[u.ID]))
 		}
 	})
 }
@@ -529,18 +741,16 @@ func TestGraphCopy(t *testing.T) {
 	}
 
 	for oldNode, oldNeighbors := range g.Adjacencies {
-		newNode := Node[string]{ID: oldNode.ID}
-		newNeighbors, exists := h.Adjacencies[newNode]
+		newNeighbors, exists := h.Adjacencies[oldNode]
 		if !exists {
-			t.Fatalf("Node %v missing in copied graph", oldNode.ID)
+			t.Fatalf("Node %v missing in copied graph", oldNode)
 		}
 
 		for oldNeighbor, oldWeight := range oldNeighbors {
-			newNeighbor := Node[string]{ID: oldNeighbor.ID}
-			newWeight, weightExists := newNeighbors[newNeighbor]
+			newWeight, weightExists := newNeighbors[oldNeighbor]
 			if !weightExists || newWeight != oldWeight {
 				t.Errorf("Edge mismatch for node %s: expected weight %f, got %f",
-					oldNode.ID, oldWeight, newWeight)
+					oldNode, oldWeight, newWeight)
 			}
 		}
 	}
@@ -550,7 +760,7 @@ func TestGraphCopy(t *testing.T) {
 	// node A that B connects to
 	// first, retrieve them
 	var copyNodeA, copyNodeB Node[string]
-	for n := range h.Adjacencies {
+	for n := range h.NodesSeq() {
 		if n.ID == "A" {
 			copyNodeA = n
 		}
@@ -560,7 +770,7 @@ func TestGraphCopy(t *testing.T) {
 	}
 	// now check
 	foundA := false
-	for neighbor := range h.Adjacencies[copyNodeB] {
+	for neighbor := range h.successorsSeq(copyNodeB) {
 		if neighbor == copyNodeA {
 			foundA = true
 		}
@@ -571,8 +781,636 @@ func TestGraphCopy(t *testing.T) {
 
 	// verify that changing the copy doesn't affect the original
 	// change the weight in the original
-	g.Adjacencies[u][v] = 10.0
-	if h.Adjacencies[u][v] == 10.0 {
+	g.Adjacencies[u.ID][v.ID] = 10.0
+	if h.Adjacencies[u.ID][v.ID] == 10.0 {
 		t.Error("Deep independence failed")
 	}
 }
+
+func TestEdgeAccessors(t *testing.T) {
+	u, v, _, _, _, _ := getNodes()
+	g := NewDirectedGraph[int]()
+	g.AddEdge(u, v, 2.5)
+
+	// read the edge back through the accessors
+	edges := g.Edges()
+	if len(edges) != 1 {
+		t.Fatalf("Expected 1 edge, got %d", len(edges))
+	}
+	e := edges[0]
+	if e.U() != u || e.V() != v || e.Weight() != 2.5 {
+		t.Errorf("Expected edge (%v, %v, %f), got (%v, %v, %f)", u, v, 2.5, e.U(), e.V(), e.Weight())
+	}
+}
+
+func TestWeightedNeighbors(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	// helper to turn adjacencies into a table to compare against
+	table := func(adjacencies []Adjancency[int]) map[Node[int]]float64 {
+		result := make(map[Node[int]]float64)
+		for _, a := range adjacencies {
+			result[a.V()] = a.Weight()
+		}
+		return result
+	}
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 2.0)
+		g.AddEdge(w, u, 3.0)
+
+		if got := table(g.WeightedSuccessors(u)); len(got) != 1 || got[v] != 2.0 {
+			t.Errorf("Expected successor %v with weight 2, got %v", v, got)
+		}
+		if got := table(g.WeightedPredecessors(u)); len(got) != 1 || got[w] != 3.0 {
+			t.Errorf("Expected predecessor %v with weight 3, got %v", w, got)
+		}
+		if got := g.WeightedNeighbors(u); len(got) != 2 {
+			t.Errorf("Expected 2 weighted neighbors, got %v", got)
+		}
+	})
+
+	t.Run("Undirected", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 2.0)
+		g.AddEdge(w, u, 3.0)
+
+		for _, got := range [][]Adjancency[int]{g.WeightedSuccessors(u), g.WeightedPredecessors(u), g.WeightedNeighbors(u)} {
+			if table := table(got); len(got) != 2 || table[v] != 2.0 || table[w] != 3.0 {
+				t.Errorf("Expected %v and %v with weights 2 and 3, got %v", v, w, got)
+			}
+		}
+	})
+
+	t.Run("Missing node", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		if got := g.WeightedSuccessors(u); got == nil || len(got) != 0 {
+			t.Errorf("Expected empty adjacencies, got %v", got)
+		}
+	})
+}
+
+func TestNewEdge(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+	g := NewDirectedGraph[int]()
+
+	// build edges the way an external caller would
+	g.AddEdgesFrom([]Edge[int]{NewEdge(u, v, 1.0), NewEdge(v, w, 3.0)})
+	if !g.HasEdge(u, v) || !g.HasEdge(v, w) {
+		t.Errorf("Expected edges from NewEdge to be added")
+	}
+	if g.Adjacencies[v.ID][w.ID] != 3.0 {
+		t.Errorf("Expected edge weight %f, got %f", 3.0, g.Adjacencies[v.ID][w.ID])
+	}
+
+	// and remove one again
+	g.RemoveEdgesFrom([]Edge[int]{NewEdge(u, v, 1.0)})
+	if g.HasEdge(u, v) {
+		t.Errorf("Expected edge from NewEdge to be removed")
+	}
+}
+
+func TestDirectedGraph_Reverse(t *testing.T) {
+	g := NewDirectedGraph[int]()
+	u, v, w, x, _, _ := getNodes()
+
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 2.0)
+	g.AddEdge(w, w, 3.0)
+	g.AddNode(x)
+
+	r := g.Reverse()
+
+	t.Run("Reversed edges", func(t *testing.T) {
+		if !r.HasEdge(v, u) || r.HasEdge(u, v) {
+			t.Errorf("Expected edge from v to u only")
+		}
+		if r.Adjacencies[w.ID][v.ID] != 2.0 {
+			t.Errorf("Expected weight %f, got %f", 2.0, r.Adjacencies[w.ID][v.ID])
+		}
+		if !r.HasEdge(w, w) || !r.HasNode(x) {
+			t.Errorf("Expected self loop and isolated node to be kept")
+		}
+	})
+
+	t.Run("Reversing twice", func(t *testing.T) {
+		if !sameAdjacencies(g.Adjacencies, r.Reverse().Adjacencies) {
+			t.Errorf("Expected reversing twice to give the original graph")
+		}
+	})
+
+	t.Run("Reverse is a copy", func(t *testing.T) {
+		r.AddEdge(x, u, 1.0)
+		if g.HasEdge(x, u) {
+			t.Errorf("Expected changes to the reverse to leave the original alone")
+		}
+	})
+}
+
+func TestSubgraph(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	t.Run("Undirected subgraph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, x, 3.0)
+		g.AddNode(y)
+
+		// z isn't part of the graph and is skipped
+		sub := g.Subgraph([]Node[int]{v, w, y, z})
+		if n := sub.NumberOfNodes(); n != 3 {
+			t.Errorf("Expected 3 nodes, got %d", n)
+		}
+		if sub.HasNode(z) {
+			t.Errorf("Expected node missing from the source to be skipped")
+		}
+		if !sub.HasEdge(w, v) || sub.Adjacencies[v.ID][w.ID] != 2.0 {
+			t.Errorf("Expected edge between v and w with weight 2.0")
+		}
+		if sub.HasEdge(u, v) || sub.HasEdge(w, x) {
+			t.Errorf("Expected edges leaving the node set to be dropped")
+		}
+	})
+
+	t.Run("Directed subgraph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, u, 5.0)
+		g.AddEdge(v, w, 2.0)
+
+		sub := g.Subgraph([]Node[int]{u, v})
+		if n := sub.NumberOfEdges(); n != 2 {
+			t.Errorf("Expected 2 edges, got %d", n)
+		}
+		if sub.Adjacencies[v.ID][u.ID] != 5.0 {
+			t.Errorf("Expected weight %f, got %f", 5.0, sub.Adjacencies[v.ID][u.ID])
+		}
+		// changes to the subgraph don't affect the original
+		sub.RemoveEdge(u, v)
+		if !g.HasEdge(u, v) {
+			t.Errorf("Expected original graph to be unchanged")
+		}
+	})
+}
+
+func TestFilter(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Filter nodes", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, x, 3.0)
+		g.SetNodeAttribute(v, "color", "red")
+
+		sub := g.FilterNodes(func(n Node[int]) bool { return n != w })
+		if sub.NumberOfNodes() != 3 || sub.NumberOfEdges() != 1 || !sub.HasEdge(u, v) {
+			t.Errorf("Expected only the edge from u to v to remain, got %v", sub.Edges())
+		}
+		if color, ok := sub.NodeAttribute(v, "color"); !ok || color != "red" {
+			t.Errorf("Expected attributes to be kept, got %v", color)
+		}
+		if g.NumberOfNodes() != 4 || g.NumberOfEdges() != 3 {
+			t.Errorf("Expected original graph to be unchanged")
+		}
+	})
+
+	t.Run("Filter edges", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, x, 3.0)
+
+		calls := 0
+		heavy := g.FilterEdges(func(a, b Node[int], weight float64) bool {
+			calls++
+			return weight >= 2.0
+		})
+		if calls != 3 {
+			t.Errorf("Expected each edge to be checked once, got %d calls", calls)
+		}
+		if heavy.NumberOfNodes() != 4 || heavy.NumberOfEdges() != 2 || heavy.HasEdge(v, u) {
+			t.Errorf("Expected only the heavy edges to remain, got %v", heavy.Edges())
+		}
+		if !g.HasEdge(u, v) {
+			t.Errorf("Expected original graph to be unchanged")
+		}
+	})
+
+	t.Run("Composed", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4})
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{2}, 5.0)
+		sub := g.FilterEdges(func(a, b Node[int], weight float64) bool { return weight < 5.0 }).
+			FilterNodes(func(n Node[int]) bool { return n.ID != 4 })
+		if sub.NumberOfNodes() != 3 || sub.NumberOfEdges() != 1 || !sub.HasEdge(Node[int]{2}, Node[int]{3}) {
+			t.Errorf("Expected only the edge between 2 and 3, got %v", sub.Edges())
+		}
+	})
+}
+
+func TestMapWeights(t *testing.T) {
+	t.Run("In place", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.MapWeights(func(w float64) float64 { return w * 3 })
+		for _, pair := range [][2]int{{1, 2}, {2, 1}, {2, 3}, {3, 2}} {
+			if w, _ := g.EdgeWeight(Node[int]{pair[0]}, Node[int]{pair[1]}); w != 3.0 {
+				t.Errorf("Expected weight 3 from %d to %d, got %f", pair[0], pair[1], w)
+			}
+			if w := g.predecessors[pair[1]][pair[0]]; w != 3.0 {
+				t.Errorf("Expected predecessor weight 3 from %d to %d, got %f", pair[0], pair[1], w)
+			}
+		}
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 2.0)
+		h := g.MappedWeights(func(w float64) float64 { return w + 1 })
+		if w, _ := h.EdgeWeight(Node[int]{1}, Node[int]{2}); w != 3.0 {
+			t.Errorf("Expected weight 3 in the copy, got %f", w)
+		}
+		if w, _ := g.EdgeWeight(Node[int]{1}, Node[int]{2}); w != 2.0 {
+			t.Errorf("Expected original weight 2 to be unchanged, got %f", w)
+		}
+	})
+
+	t.Run("Longest path by negation", func(t *testing.T) {
+		// the longest path from 1 to 4 goes the long way round through 3
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 5.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 2.0)
+		g.AddEdge(Node[int]{3}, Node[int]{2}, 4.0)
+		g.AddEdge(Node[int]{2}, Node[int]{4}, 1.0)
+		negated := g.MappedWeights(func(w float64) float64 { return -w })
+		distances, previous, err := negated.BellmanFord(Node[int]{1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if longest := -distances[Node[int]{4}]; longest != 7.0 {
+			t.Errorf("Expected longest path of 7, got %f", longest)
+		}
+		path, _ := ReconstructPath(previous, Node[int]{1}, Node[int]{4})
+		if expected := (Path[int]{{1}, {3}, {2}, {4}}); path.String() != expected.String() {
+			t.Errorf("Expected %v, got %v", expected, path)
+		}
+	})
+}
+
+func TestUndirectedGraph_Edges(t *testing.T) {
+	t.Run("Undirected edges are reported once", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		u, v, w, _, _, _ := getNodes()
+
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, w, 3.0)
+
+		edges := g.Edges()
+		if len(edges) != 3 {
+			t.Fatalf("Expected 3 edges, got %v", edges)
+		}
+		// each pair shows up in exactly one orientation
+		for _, e := range edges {
+			if e.u != e.v && slices.Contains(edges, Edge[int]{e.v, e.u, e.weight}) {
+				t.Errorf("Expected edge %v only once, got %v", e, edges)
+			}
+		}
+		if n := g.NumberOfEdges(); n != 3 {
+			t.Errorf("Expected 3 edges, got %d", n)
+		}
+	})
+
+	t.Run("Undirected edges have a canonical orientation", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		u, v, w, x, _, _ := getNodes()
+
+		// add edges with the larger ID first
+		g.AddEdge(v, u, 1.0)
+		g.AddEdge(x, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+
+		for range 10 {
+			for _, e := range g.Edges() {
+				if e.u.ID > e.v.ID {
+					t.Fatalf("Expected smaller ID first, got %v", e)
+				}
+			}
+		}
+
+		// IDs without a natural order still get a stable orientation
+		h := NewUndirectedGraph[Coordinate]()
+		a := Node[Coordinate]{Coordinate{1, 0}}
+		b := Node[Coordinate]{Coordinate{0, 1}}
+		h.AddEdge(a, b, 1.0)
+		if e := h.Edges()[0]; e.u != b {
+			t.Errorf("Expected %v first, got %v", b, e)
+		}
+	})
+}
+
+func TestUndirectedEdgeCount(t *testing.T) {
+	t.Run("Matches the edges", func(t *testing.T) {
+		g := GridGraph(4, 3)
+		g.AddEdge(Node[Coordinate]{Coordinate{0, 0}}, Node[Coordinate]{Coordinate{0, 0}}, 1.0)
+		// an edge only stored one way still counts once
+		g.setEdge(Node[Coordinate]{Coordinate{0, 0}}, Node[Coordinate]{Coordinate{3, 2}}, 1.0)
+		if n, edges := g.NumberOfEdges(), len(g.Edges()); n != edges || n != 19 {
+			t.Errorf("Expected 19 edges, got %d counted and %d listed", n, edges)
+		}
+	})
+
+	t.Run("Counting doesn't allocate", func(t *testing.T) {
+		g := GridGraph(20, 20)
+		if allocs := testing.AllocsPerRun(10, func() { g.NumberOfEdges() }); allocs != 0 {
+			t.Errorf("Expected no allocations, got %f", allocs)
+		}
+	})
+}
+
+func TestIteratorsMatchSlices(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	// run the same checks against both graph types through the interface
+	graphs := map[string]Graph[int]{
+		"directed":   NewDirectedGraph[int](),
+		"undirected": NewUndirectedGraph[int](),
+	}
+	for name, g := range graphs {
+		t.Run("Iterators for "+name+" graph", func(t *testing.T) {
+			g.AddEdge(u, v, 1.0)
+			g.AddEdge(v, w, 2.0)
+			g.AddEdge(w, w, 3.0)
+
+			nodes := slices.Collect(g.NodesSeq())
+			if len(nodes) != len(g.Nodes()) {
+				t.Errorf("Expected %d nodes from iterator, got %d", len(g.Nodes()), len(nodes))
+			}
+			edges := slices.Collect(g.EdgesSeq())
+			if len(edges) != g.NumberOfEdges() {
+				t.Errorf("Expected %d edges from iterator, got %d", g.NumberOfEdges(), len(edges))
+			}
+			for _, e := range g.Edges() {
+				// the undirected iterator doesn't orient edges like Edges does
+				flipped := NewEdge(e.v, e.u, e.weight)
+				if !slices.Contains(edges, e) && (name == "directed" || !slices.Contains(edges, flipped)) {
+					t.Errorf("Expected iterator to yield %v", e)
+				}
+			}
+			// stopping early is fine
+			for range g.EdgesSeq() {
+				break
+			}
+		})
+	}
+}
+
+// helper to check that the predecessor index is exactly the
+// adjacencies turned around
+func consistentPredecessors[K comparable](g *graphData[K]) bool {
+	if len(g.predecessors) != len(g.Adjacencies) {
+		return false
+	}
+	count := 0
+	for u, neighbors := range g.Adjacencies {
+		for v, w := range neighbors {
+			if pw, ok := g.predecessors[v][u]; !ok || pw != w {
+				return false
+			}
+			count++
+		}
+	}
+	for _, preds := range g.predecessors {
+		count -= len(preds)
+	}
+	return count == 0
+}
+
+func TestPredecessorIndex(t *testing.T) {
+	u, v, w, x, y, _ := getNodes()
+
+	t.Run("Directed removals", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(w, v, 2.0)
+		g.AddEdge(v, x, 3.0)
+		g.AddEdge(x, x, 4.0)
+		g.AddEdge(y, v, 5.0)
+
+		if d := g.InDegree(v); d != 3 {
+			t.Errorf("Expected in-degree 3 for v, got %d", d)
+		}
+		g.RemoveEdge(w, v)
+		g.RemoveEdgesFrom([]Edge[int]{NewEdge(y, v, 5.0)})
+		if d := g.InDegree(v); d != 1 {
+			t.Errorf("Expected in-degree 1 for v after removing edges, got %d", d)
+		}
+		g.RemoveNode(v)
+		if d := g.InDegree(x); d != 1 {
+			t.Errorf("Expected only the self-loop into x, got in-degree %d", d)
+		}
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies")
+		}
+		g.RemoveNode(x)
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies")
+		}
+	})
+
+	t.Run("Undirected removals", func(t *testing.T) {
+		g := CompleteGraph([]int{1, 2, 3, 4})
+		g.RemoveEdge(Node[int]{1}, Node[int]{2})
+		g.RemoveNode(Node[int]{3})
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies")
+		}
+		if p := g.graphData.Predecessors(Node[int]{1}); len(p) != 1 || p[0] != (Node[int]{4}) {
+			t.Errorf("Expected 4 as the only predecessor of 1, got %v", p)
+		}
+	})
+
+	t.Run("Whole graph operations", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+		g.AddEdge(x, v, 1.0)
+
+		for name, h := range map[string]*graphData[int]{
+			"copy":       g.Copy(),
+			"subgraph":   &g.Subgraph([]Node[int]{u, v, x}).graphData,
+			"complement": &g.Complement().graphData,
+		} {
+			if !consistentPredecessors(h) {
+				t.Errorf("Expected predecessor index of %s to match adjacencies", name)
+			}
+		}
+
+		g.ContractEdge(u, v)
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies after contraction")
+		}
+		g.Relabel(func(id int) int { return id * 10 })
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match adjacencies after relabeling")
+		}
+		g.Clear()
+		if !consistentPredecessors(&g.graphData) || g.InDegree(Node[int]{10}) != 0 {
+			t.Errorf("Expected empty predecessor index after clearing")
+		}
+	})
+}
+
+func TestReserve(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Existing contents are kept", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.Reserve(100, 1000)
+		g.AddEdge(v, w, 2.5)
+		if g.NumberOfNodes() != 3 || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected 3 nodes and 2 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if g.Adjacencies[u.ID][v.ID] != 1.5 || g.InDegree(v) != 1 {
+			t.Errorf("Expected edge from u to v to survive reserving")
+		}
+	})
+
+	t.Run("Adding with a hint", func(t *testing.T) {
+		edges := []Edge[int]{NewEdge(u, v, 1.0), NewEdge(v, w, 2.0)}
+		d := NewDirectedGraph[int]()
+		d.AddEdgesFromWithHint(edges, 3)
+		h := NewUndirectedGraph[int]()
+		h.AddEdgesFromWithHint(edges, 3)
+		if d.NumberOfEdges() != 2 || h.NumberOfEdges() != 2 || !h.HasEdge(w, v) {
+			t.Errorf("Expected 2 edges in each graph, got %v and %v", d.Edges(), h.Edges())
+		}
+	})
+}
+
+// helper to create the edges of a sparse random-ish graph
+func benchmarkEdges(nodes, degree int) []Edge[int] {
+	edges := make([]Edge[int], 0, nodes*degree)
+	for u := range nodes {
+		for i := 1; i <= degree; i++ {
+			edges = append(edges, NewEdge(Node[int]{u}, Node[int]{(u * i * 7919) % nodes}, 1.0))
+		}
+	}
+	return edges
+}
+
+func BenchmarkAddEdgesFrom(b *testing.B) {
+	edges := benchmarkEdges(2000, 32)
+	b.ReportAllocs()
+	for b.Loop() {
+		g := NewDirectedGraph[int]()
+		g.AddEdgesFrom(edges)
+	}
+}
+
+func BenchmarkAddEdgesFromWithHint(b *testing.B) {
+	edges := benchmarkEdges(2000, 32)
+	b.ReportAllocs()
+	for b.Loop() {
+		g := NewDirectedGraph[int]()
+		g.AddEdgesFromWithHint(edges, 2000)
+	}
+}
+
+func TestAdjacenciesKeyedByID(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.AddEdge(w, v, 2.5)
+
+		// the tables hold bare IDs
+		expected := map[int]map[int]float64{1: {2: 1.5}, 2: {}, 3: {2: 2.5}}
+		if !sameAdjacencies(g.Adjacencies, expected) {
+			t.Errorf("Expected %v, got %v", expected, g.Adjacencies)
+		}
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match the adjacencies")
+		}
+
+		// and the API hands out nodes
+		if nodes := sortedNodes(g.Nodes()); !slices.Equal(nodes, []Node[int]{u, v, w}) {
+			t.Errorf("Expected nodes %v, got %v", []Node[int]{u, v, w}, nodes)
+		}
+		if preds := sortedNodes(g.Predecessors(v)); !slices.Equal(preds, []Node[int]{u, w}) {
+			t.Errorf("Expected predecessors %v, got %v", []Node[int]{u, w}, preds)
+		}
+		if succs := g.Successors(u); !slices.Equal(succs, []Node[int]{v}) {
+			t.Errorf("Expected successors %v, got %v", []Node[int]{v}, succs)
+		}
+		edges := sortedEdges(g.Edges())
+		if len(edges) != 2 || edges[0] != NewEdge(u, v, 1.5) || edges[1] != NewEdge(w, v, 2.5) {
+			t.Errorf("Expected edges u->v and w->v, got %v", edges)
+		}
+	})
+
+	t.Run("Undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.AddEdge(v, v, 3.0)
+
+		expected := map[int]map[int]float64{1: {2: 1.5}, 2: {1: 1.5, 2: 3.0}}
+		if !sameAdjacencies(g.Adjacencies, expected) {
+			t.Errorf("Expected %v, got %v", expected, g.Adjacencies)
+		}
+		g.RemoveNode(v)
+		if !sameAdjacencies(g.Adjacencies, map[int]map[int]float64{1: {}}) {
+			t.Errorf("Expected only u without edges, got %v", g.Adjacencies)
+		}
+		if !consistentPredecessors(&g.graphData) {
+			t.Errorf("Expected predecessor index to match the adjacencies")
+		}
+	})
+}
+
+func TestSortedAccessors(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+	greater := func(a, b int) bool { return a > b }
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(w, u, 1.0)
+		g.AddEdge(u, w, 2.0)
+		g.AddEdge(u, v, 3.0)
+
+		if nodes := g.SortedNodes(nil); !slices.Equal(nodes, []Node[int]{u, v, w}) {
+			t.Errorf("Expected nodes in ID order, got %v", nodes)
+		}
+		if nodes := g.SortedNodes(greater); !slices.Equal(nodes, []Node[int]{w, v, u}) {
+			t.Errorf("Expected nodes in reverse ID order, got %v", nodes)
+		}
+		expected := []Edge[int]{NewEdge(u, v, 3.0), NewEdge(u, w, 2.0), NewEdge(w, u, 1.0)}
+		if edges := g.SortedEdges(nil); !slices.Equal(edges, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, edges)
+		}
+		slices.Reverse(expected)
+		if edges := g.SortedEdges(greater); !slices.Equal(edges, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, edges)
+		}
+	})
+
+	t.Run("Undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[string]()
+		a, b, c := Node[string]{"a"}, Node[string]{"b"}, Node[string]{"c"}
+		g.AddEdge(c, a, 1.0)
+		g.AddEdge(b, a, 2.0)
+
+		// edges are reported once each, smaller ID first
+		expected := []Edge[string]{NewEdge(a, b, 2.0), NewEdge(a, c, 1.0)}
+		if edges := g.SortedEdges(nil); !slices.Equal(edges, expected) {
+			t.Errorf("Expected edges %v, got %v", expected, edges)
+		}
+		if nodes := g.SortedNodes(nil); !slices.Equal(nodes, []Node[string]{a, b, c}) {
+			t.Errorf("Expected nodes in ID order, got %v", nodes)
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// coordinates have X and Y components
+type Coordinate struct {
+	X, Y int
+}
+
+// they implement Stringer for easy printing
+func (c Coordinate) String() string {
+	return fmt.Sprintf("(%d, %d)", c.X, c.Y)
+}
+
+// directions for walking a grid are really just coordinates:
+// {0, 1}, {0, -1}, {1, 0}, {-1, 0}
+type Direction Coordinate
+
+// the four cardinal directions
+var CardinalDirections = []Direction{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+}
+
+// the four cardinal directions plus the four diagonals
+var AllDirections = []Direction{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+// build an undirected graph from a grid given as lines of text. every
+// tile matching the walkable rune becomes a node, even if it has no
+// walkable neighbors, and neighboring walkable tiles in any of the
+// given directions are connected with an edge of weight 1.0. diagonal
+// moves are allowed even if both tiles beside them are walls. trailing
+// empty lines are ignored, and empty lines inside the grid are rows of
+// walls
+func BuildGridGraph(grid []string, directions []Direction, walkable rune) (*UndirectedGraph[Coordinate], error) {
+	g := NewUndirectedGraph[Coordinate]()
+	err := walkGrid(grid, directions, unitCost(walkable), true, nil, g.AddNode, g.AddEdge)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// like BuildGridGraph, but diagonal moves are only allowed if both
+// orthogonal tiles they pass are walkable, so walls can't be
+// squeezed past at their corners
+func BuildGridGraphNoCornerCutting(grid []string, directions []Direction, walkable rune) (*UndirectedGraph[Coordinate], error) {
+	g := NewUndirectedGraph[Coordinate]()
+	err := walkGrid(grid, directions, unitCost(walkable), false, nil, g.AddNode, g.AddEdge)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// build a graph from a grid where tiles cost different amounts to enter.
+// cost returns the weight of entering a tile and whether it's walkable
+// at all. since moving from a to b can cost something different than
+// moving from b to a, the result is a directed graph with an edge in
+// each direction between neighboring walkable tiles
+func BuildWeightedGridGraph(grid []string, directions []Direction, cost func(rune) (float64, bool)) (*DirectedGraph[Coordinate], error) {
+	g := NewDirectedGraph[Coordinate]()
+	err := walkGrid(grid, directions, cost, true, nil, g.AddNode, g.AddEdge)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// build an undirected graph from a grid, letting the caller decide
+// which tiles are walkable. visit is called with every tile on the grid,
+// walls included, so callers can collect start, target, or any other
+// special tiles while the graph is built. neighboring walkable tiles in
+// any of the given directions are connected with an edge of weight 1.0
+func BuildGridGraphFunc(grid []string, directions []Direction, walkable func(rune) bool, visit func(r rune, c Coordinate)) (*UndirectedGraph[Coordinate], error) {
+	cost := func(r rune) (float64, bool) {
+		return 1.0, walkable(r)
+	}
+	g := NewUndirectedGraph[Coordinate]()
+	err := walkGrid(grid, directions, cost, true, visit, g.AddNode, g.AddEdge)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// like BuildGridGraphFunc, but reads the grid line by line from a
+// reader instead of taking it already split. rows are buffered as
+// they arrive, since building the edges of a row needs the rows
+// around it
+func BuildGridGraphFromReader(r io.Reader, directions []Direction, walkable func(rune) bool, visit func(r rune, c Coordinate)) (*UndirectedGraph[Coordinate], error) {
+	grid, err := readGrid(r)
+	if err != nil {
+		return nil, err
+	}
+	return BuildGridGraphFunc(grid, directions, walkable, visit)
+}
+
+// like BuildGridGraph, but walkable tiles on either side of a single
+// wall are also connected straight through it, with an edge of weight
+// breakCost standing in for both steps. that lets DijkstraTo find paths
+// that punch through walls where it pays off. a shortcut only ever
+// crosses one wall tile, so two walls in a row can't be broken through.
+// the graph doesn't limit how many shortcuts a path takes, so puzzles
+// that allow a single break need a breakCost high enough to rule out a
+// second one, or a search that tracks whether a wall was broken already
+func BuildGridGraphWithBreakable(grid []string, directions []Direction, walkable rune, breakCost float64) (*UndirectedGraph[Coordinate], error) {
+	g := NewUndirectedGraph[Coordinate]()
+	// collect the walls on the way, tiles past the end of a row aren't
+	// on the grid and can't be broken through
+	walls := make([]Coordinate, 0)
+	visit := func(r rune, c Coordinate) {
+		if r != walkable {
+			walls = append(walls, c)
+		}
+	}
+	err := walkGrid(grid, directions, unitCost(walkable), true, visit, g.AddNode, g.AddEdge)
+	if err != nil {
+		return nil, err
+	}
+
+	// connect the tiles on opposite sides of every wall
+	for _, c := range walls {
+		for _, d := range directions {
+			u := Node[Coordinate]{Coordinate{c.X - d.X, c.Y - d.Y}}
+			v := Node[Coordinate]{Coordinate{c.X + d.X, c.Y + d.Y}}
+			if g.HasNode(u) && g.HasNode(v) {
+				g.AddEdge(u, v, breakCost)
+			}
+		}
+	}
+	return g, nil
+}
+
+// helper to read the lines of a grid from a reader
+func readGrid(r io.Reader) ([]string, error) {
+	grid := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	// allow rows longer than the scanner's default limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		grid = append(grid, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+// helper to create a cost function where a single rune is walkable
+// and every step costs 1.0
+func unitCost(walkable rune) func(rune) (float64, bool) {
+	return func(r rune) (float64, bool) {
+		return 1.0, r == walkable
+	}
+}
+
+// helper to walk a grid and report its walkable tiles and the moves
+// between them. cost decides whether a tile is walkable and what it
+// costs to enter it. if visit isn't nil, it's called with every tile.
+// every walkable tile is passed to addNode, and every move from a
+// walkable tile to a walkable neighbor in one of the directions is
+// passed to addEdge along with the cost of the neighbor. empty lines at
+// the end of the grid are dropped, but empty lines in between are kept
+// as rows without any tiles, so they act like a row of walls
+func walkGrid(grid []string, directions []Direction, cost func(rune) (float64, bool), cornerCutting bool, visit func(rune, Coordinate), addNode func(Node[Coordinate]), addEdge func(u, v Node[Coordinate], w float64)) error {
+	// a trailing newline leaves an empty last line behind, it isn't a
+	// row. neither are any other empty lines at the end
+	for len(grid) > 0 && strings.TrimSuffix(grid[len(grid)-1], "\r") == "" {
+		grid = grid[:len(grid)-1]
+	}
+	if len(grid) == 0 {
+		return errors.New("grid is empty")
+	}
+
+	// turn the grid into 2d runes so that multi-byte tiles line up.
+	// lines split from CRLF input still end in \r, which isn't a tile
+	tiles := make([][]rune, len(grid))
+	for y, line := range grid {
+		tiles[y] = []rune(strings.TrimSuffix(line, "\r"))
+	}
+
+	// function to look up the cost of a tile, and whether it's on
+	// the grid and walkable. rows may differ in length
+	tileCost := func(x, y int) (float64, bool) {
+		if y < 0 || y >= len(tiles) || x < 0 || x >= len(tiles[y]) {
+			return 0.0, false
+		}
+		return cost(tiles[y][x])
+	}
+	isWalkable := func(x, y int) bool {
+		_, ok := tileCost(x, y)
+		return ok
+	}
+
+	// walk the grid
+	for y, row := range tiles {
+		for x, c := range row {
+			// let the caller see every tile
+			if visit != nil {
+				visit(c, Coordinate{x, y})
+			}
+			// on a wall, this isn't a valid node
+			if !isWalkable(x, y) {
+				continue
+			}
+			// on a walkable tile, create a node for it
+			u := Node[Coordinate]{Coordinate{x, y}}
+			addNode(u)
+			// and explore its neighbors
+			for _, d := range directions {
+				// calculate the neighbor coordinates
+				new_x, new_y := x+d.X, y+d.Y
+				// is the neighbor walkable?
+				w, ok := tileCost(new_x, new_y)
+				if !ok {
+					continue
+				}
+				// diagonal moves may not squeeze past the corners of walls
+				if !cornerCutting && d.X != 0 && d.Y != 0 {
+					if !isWalkable(new_x, y) || !isWalkable(x, new_y) {
+						continue
+					}
+				}
+				// add an edge between them
+				v := Node[Coordinate]{Coordinate{new_x, new_y}}
+				addEdge(u, v, w)
+			}
+		}
+	}
+
+	return nil
+}
+
+// find the start tile marked 'S' and the target tile marked 'T' in a
+// grid. returns a copy of the grid with both replaced by the walkable
+// rune, ready to be passed to BuildGridGraph, as well as the two nodes
+func FindStartTarget(grid []string, walkable rune) ([]string, Node[Coordinate], Node[Coordinate], error) {
+	var start, target Node[Coordinate]
+	foundStart, foundTarget := false, false
+
+	cleaned := make([]string, len(grid))
+	for y, line := range grid {
+		row := []rune(line)
+		for x, c := range row {
+			// record the start and end tiles, and turn them into normal tiles
+			if c == 'S' {
+				start = Node[Coordinate]{Coordinate{x, y}}
+				foundStart = true
+				row[x] = walkable
+			}
+			if c == 'T' {
+				target = Node[Coordinate]{Coordinate{x, y}}
+				foundTarget = true
+				row[x] = walkable
+			}
+		}
+		cleaned[y] = string(row)
+	}
+
+	if !foundStart || !foundTarget {
+		return nil, start, target, errors.New("grid is missing a start or target tile")
+	}
+	return cleaned, start, target, nil
+}
+
+// find the node of a grid graph closest to a coordinate, for example to
+// snap a start point that landed on a wall to the nearest walkable tile.
+// distance is Manhattan distance, the number of cardinal steps apart,
+// and ties go to the node first in reading order, top to bottom and
+// left to right. returns false if the graph has no nodes
+func NearestNode(g *UndirectedGraph[Coordinate], c Coordinate) (Node[Coordinate], bool) {
+	var nearest Node[Coordinate]
+	best := -1
+	for n := range g.NodesSeq() {
+		d := abs(n.ID.X-c.X) + abs(n.ID.Y-c.Y)
+		closer := best < 0 || d < best
+		// break ties by reading order, so the result doesn't depend on
+		// the order the nodes come out of the map
+		if d == best && (n.ID.Y < nearest.ID.Y || (n.ID.Y == nearest.ID.Y && n.ID.X < nearest.ID.X)) {
+			closer = true
+		}
+		if closer {
+			nearest, best = n, d
+		}
+	}
+	return nearest, best >= 0
+}
+
+// helper to get the absolute value of an int
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// function to draw a grid graph back out as text, for debugging. the
+// grid is width tiles wide and height tiles high, starting at (0, 0).
+// tiles that are nodes are drawn as '.', all others as '#', unless mark
+// has a rune for them, like '*' for the tiles of a path. mark may be
+// nil. every row ends in a newline
+func RenderGrid(g *UndirectedGraph[Coordinate], width, height int, mark map[Coordinate]rune) string {
+	var sb strings.Builder
+	for y := range height {
+		for x := range width {
+			c := Coordinate{x, y}
+			if r, ok := mark[c]; ok {
+				sb.WriteRune(r)
+			} else if g.HasNode(Node[Coordinate]{c}) {
+				sb.WriteRune('.')
+			} else {
+				sb.WriteRune('#')
+			}
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// function to draw a path onto the grid it was found in, for checking
+// the result of a search by eye. every tile on the path is replaced by
+// glyph, except the 'S' and 'T' markers, which stay put. coordinates
+// off the grid are skipped. every row ends in a newline, like with
+// RenderGrid
+func RenderPath(grid []string, p Path[Coordinate], glyph rune) string {
+	tiles := make([][]rune, len(grid))
+	for y, line := range grid {
+		tiles[y] = []rune(strings.TrimSuffix(line, "\r"))
+	}
+	for _, n := range p {
+		x, y := n.ID.X, n.ID.Y
+		if y < 0 || y >= len(tiles) || x < 0 || x >= len(tiles[y]) {
+			continue
+		}
+		if tiles[y][x] != 'S' && tiles[y][x] != 'T' {
+			tiles[y][x] = glyph
+		}
+	}
+
+	var sb strings.Builder
+	for _, row := range tiles {
+		sb.WriteString(string(row))
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
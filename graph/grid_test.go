@@ -0,0 +1,476 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildGridGraph(t *testing.T) {
+	grid := []string{
+		"#####",
+		"#S..#",
+		"#.#.#",
+		"#..T#",
+		"##.##",
+	}
+
+	t.Run("Find start and target", func(t *testing.T) {
+		cleaned, s, target, err := FindStartTarget(grid, '.')
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.ID != (Coordinate{1, 1}) || target.ID != (Coordinate{3, 3}) {
+			t.Errorf("Expected start (1, 1) and target (3, 3), got %v and %v", s.ID, target.ID)
+		}
+		if cleaned[1] != "#...#" || cleaned[3] != "#...#" {
+			t.Errorf("Expected start and target to be replaced, got %v", cleaned)
+		}
+		// the original grid is left alone
+		if grid[1] != "#S..#" {
+			t.Errorf("Expected original grid to be unchanged, got %v", grid)
+		}
+	})
+
+	t.Run("Missing start or target", func(t *testing.T) {
+		if _, _, _, err := FindStartTarget([]string{"#S.#"}, '.'); err == nil {
+			t.Errorf("Expected error for grid without target")
+		}
+	})
+
+	t.Run("Build graph from grid", func(t *testing.T) {
+		cleaned, s, target, _ := FindStartTarget(grid, '.')
+		g, err := BuildGridGraph(cleaned, CardinalDirections, '.')
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if n := g.NumberOfNodes(); n != 9 {
+			t.Errorf("Expected 9 walkable tiles, got %d", n)
+		}
+		// the bottom exit only connects upwards
+		exit := Node[Coordinate]{Coordinate{2, 4}}
+		if g.Degree(exit) != 1 || !g.HasEdge(exit, Node[Coordinate]{Coordinate{2, 3}}) {
+			t.Errorf("Expected exit tile to have a single edge upwards")
+		}
+		// and there's a path around the center wall
+		_, l := g.BFS(s, target)
+		if l != 5 {
+			t.Errorf("Expected shortest path over 5 tiles, got %d", l)
+		}
+	})
+
+	t.Run("Isolated walkable tile", func(t *testing.T) {
+		g, _ := BuildGridGraph([]string{".#."}, CardinalDirections, '.')
+		if n := g.NumberOfNodes(); n != 2 {
+			t.Errorf("Expected 2 nodes, got %d", n)
+		}
+		if n := g.NumberOfEdges(); n != 0 {
+			t.Errorf("Expected 0 edges, got %d", n)
+		}
+	})
+
+	t.Run("Empty grid", func(t *testing.T) {
+		if _, err := BuildGridGraph(nil, CardinalDirections, '.'); err == nil {
+			t.Errorf("Expected error for empty grid")
+		}
+	})
+}
+
+func TestBuildGridGraphDiagonals(t *testing.T) {
+	// two open tiles touching only at their corners
+	grid := []string{
+		".#",
+		"#.",
+	}
+	a := Node[Coordinate]{Coordinate{0, 0}}
+	b := Node[Coordinate]{Coordinate{1, 1}}
+
+	t.Run("Cardinal directions don't connect diagonals", func(t *testing.T) {
+		g, _ := BuildGridGraph(grid, CardinalDirections, '.')
+		if g.HasEdge(a, b) {
+			t.Errorf("Expected no diagonal edge with cardinal directions")
+		}
+	})
+
+	t.Run("All directions connect diagonals", func(t *testing.T) {
+		g, _ := BuildGridGraph(grid, AllDirections, '.')
+		if !g.HasEdge(a, b) {
+			t.Fatalf("Expected diagonal edge past the corner walls")
+		}
+		if g.Adjacencies[a.ID][b.ID] != 1.0 {
+			t.Errorf("Expected diagonal edge weight of 1.0, got %f", g.Adjacencies[a.ID][b.ID])
+		}
+	})
+
+	t.Run("Corner cutting can be disallowed", func(t *testing.T) {
+		g, _ := BuildGridGraphNoCornerCutting(grid, AllDirections, '.')
+		if g.HasEdge(a, b) {
+			t.Errorf("Expected diagonal edge past corner walls to be blocked")
+		}
+		// an open 2x2 block still has its diagonals
+		h, _ := BuildGridGraphNoCornerCutting([]string{"..", ".."}, AllDirections, '.')
+		if !h.HasEdge(a, b) {
+			t.Errorf("Expected diagonal edge in open area")
+		}
+		if n := h.Degree(a); n != 3 {
+			t.Errorf("Expected degree of 3, got %d", n)
+		}
+	})
+}
+
+func TestBuildWeightedGridGraph(t *testing.T) {
+	// digits are terrain costs, '#' is a wall
+	grid := []string{
+		"1991",
+		"1#11",
+		"1111",
+	}
+	cost := func(r rune) (float64, bool) {
+		if r == '#' {
+			return 0.0, false
+		}
+		return float64(r - '0'), true
+	}
+
+	g, err := BuildWeightedGridGraph(grid, CardinalDirections, cost)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Edge weights are the cost of entering", func(t *testing.T) {
+		a := Node[Coordinate]{Coordinate{0, 0}}
+		b := Node[Coordinate]{Coordinate{1, 0}}
+		if g.Adjacencies[a.ID][b.ID] != 9.0 || g.Adjacencies[b.ID][a.ID] != 1.0 {
+			t.Errorf("Expected weights 9.0 and 1.0, got %f and %f", g.Adjacencies[a.ID][b.ID], g.Adjacencies[b.ID][a.ID])
+		}
+	})
+
+	t.Run("Least cost path avoids expensive terrain", func(t *testing.T) {
+		s := Node[Coordinate]{Coordinate{0, 0}}
+		target := Node[Coordinate]{Coordinate{3, 0}}
+		_, l, c := g.DijkstraTo(s, target)
+		// going around the bottom takes more steps, but costs less
+		if c != 7.0 || l != 8 {
+			t.Errorf("Expected path over 8 tiles with cost 7.0, got %d and %f", l, c)
+		}
+	})
+}
+
+func TestBuildGridGraphFunc(t *testing.T) {
+	// several starts and an exit, with special tiles being walkable
+	grid := []string{
+		"S.#E",
+		"..S.",
+	}
+	starts := make([]Coordinate, 0)
+	var exit Coordinate
+	visited := 0
+
+	walkable := func(r rune) bool {
+		return r != '#'
+	}
+	visit := func(r rune, c Coordinate) {
+		visited++
+		switch r {
+		case 'S':
+			starts = append(starts, c)
+		case 'E':
+			exit = c
+		}
+	}
+
+	g, err := BuildGridGraphFunc(grid, CardinalDirections, walkable, visit)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Callback sees every tile", func(t *testing.T) {
+		if visited != 8 {
+			t.Errorf("Expected 8 tiles to be visited, got %d", visited)
+		}
+		if len(starts) != 2 || exit != (Coordinate{3, 0}) {
+			t.Errorf("Expected 2 starts and an exit at (3, 0), got %v and %v", starts, exit)
+		}
+	})
+
+	t.Run("Special tiles are part of the graph", func(t *testing.T) {
+		if n := g.NumberOfNodes(); n != 7 {
+			t.Errorf("Expected 7 nodes, got %d", n)
+		}
+		for _, s := range starts {
+			if _, l := g.BFS(Node[Coordinate]{s}, Node[Coordinate]{exit}); l == 0 {
+				t.Errorf("Expected exit to be reachable from %v", s)
+			}
+		}
+	})
+}
+
+func TestBuildGridGraphFromReader(t *testing.T) {
+	grid := []string{
+		"#####",
+		"#S..#",
+		"#.#.#",
+		"#..T#",
+	}
+	walkable := func(r rune) bool {
+		return r != '#'
+	}
+
+	expected, _ := BuildGridGraphFunc(grid, CardinalDirections, walkable, nil)
+	var start Coordinate
+	visit := func(r rune, c Coordinate) {
+		if r == 'S' {
+			start = c
+		}
+	}
+	g, err := BuildGridGraphFromReader(strings.NewReader(strings.Join(grid, "\n")+"\n"), CardinalDirections, walkable, visit)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !sameAdjacencies(g.Adjacencies, expected.Adjacencies) {
+		t.Errorf("Expected the same graph as from the split grid, got %v", g.Edges())
+	}
+	if start != (Coordinate{1, 1}) {
+		t.Errorf("Expected start at (1, 1), got %v", start)
+	}
+
+	t.Run("Empty reader", func(t *testing.T) {
+		if _, err := BuildGridGraphFromReader(strings.NewReader(""), CardinalDirections, walkable, nil); err == nil {
+			t.Errorf("Expected error for an empty grid")
+		}
+	})
+}
+
+func TestGridLineEndings(t *testing.T) {
+	rows := []string{
+		"#S..#",
+		"#.#.#",
+		"#..T#",
+	}
+	walkable := func(r rune) bool {
+		return r != '#'
+	}
+	expected, err := BuildGridGraphFunc(rows, AllDirections, walkable, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// the same grid split from files with different line endings
+	inputs := map[string]string{
+		"LF":                strings.Join(rows, "\n"),
+		"LF with newline":   strings.Join(rows, "\n") + "\n",
+		"CRLF":              strings.Join(rows, "\r\n"),
+		"CRLF with newline": strings.Join(rows, "\r\n") + "\r\n",
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			tiles := 0
+			visit := func(r rune, c Coordinate) {
+				tiles++
+			}
+			g, err := BuildGridGraphFunc(strings.Split(input, "\n"), AllDirections, walkable, visit)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tiles != 15 {
+				t.Errorf("Expected 15 tiles, got %d", tiles)
+			}
+			if !sameAdjacencies(g.Adjacencies, expected.Adjacencies) {
+				t.Errorf("Expected the same graph for %s input, got %v", name, g.Edges())
+			}
+		})
+	}
+}
+
+func TestGridBlankLines(t *testing.T) {
+	walkable := func(r rune) bool {
+		return r == '.'
+	}
+
+	t.Run("Trailing empty lines are ignored", func(t *testing.T) {
+		expected, _ := BuildGridGraphFunc([]string{"..", ".."}, CardinalDirections, walkable, nil)
+		g, err := BuildGridGraphFunc([]string{"..", "..", "", "\r", ""}, CardinalDirections, walkable, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, expected.Adjacencies) {
+			t.Errorf("Expected trailing empty lines not to change the graph, got %v", g.Edges())
+		}
+	})
+
+	t.Run("Only empty lines", func(t *testing.T) {
+		if _, err := BuildGridGraphFunc([]string{"", ""}, CardinalDirections, walkable, nil); err == nil {
+			t.Errorf("Expected error for a grid of empty lines")
+		}
+	})
+
+	t.Run("Interior empty lines are walls", func(t *testing.T) {
+		g, err := BuildGridGraphFunc([]string{"..", "", ".."}, CardinalDirections, walkable, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if g.NumberOfNodes() != 4 || g.NumberOfComponents() != 2 {
+			t.Errorf("Expected two separate pairs of tiles, got %v", g.Edges())
+		}
+		if !g.HasNode(Node[Coordinate]{Coordinate{0, 2}}) {
+			t.Errorf("Expected the row after the blank line to keep its y coordinate")
+		}
+	})
+}
+
+func TestNearestNode(t *testing.T) {
+	grid := []string{
+		"#.#",
+		"###",
+		".#.",
+	}
+	g, _ := BuildGridGraph(grid, CardinalDirections, '.')
+
+	t.Run("On a node", func(t *testing.T) {
+		n, ok := NearestNode(g, Coordinate{2, 2})
+		if !ok || n.ID != (Coordinate{2, 2}) {
+			t.Errorf("Expected (2, 2), got %v and %t", n, ok)
+		}
+	})
+
+	t.Run("On a wall", func(t *testing.T) {
+		// (0, 2) is one step away, (1, 0) two
+		n, ok := NearestNode(g, Coordinate{0, 1})
+		if !ok || n.ID != (Coordinate{0, 2}) {
+			t.Errorf("Expected (0, 2), got %v and %t", n, ok)
+		}
+	})
+
+	t.Run("Ties go to reading order", func(t *testing.T) {
+		// (1, 0), (0, 2), and (2, 2) are all two steps away
+		n, ok := NearestNode(g, Coordinate{1, 1})
+		if !ok || n.ID != (Coordinate{1, 0}) {
+			t.Errorf("Expected (1, 0), got %v and %t", n, ok)
+		}
+		n, _ = NearestNode(g, Coordinate{1, 3})
+		if n.ID != (Coordinate{0, 2}) {
+			t.Errorf("Expected (0, 2), got %v", n)
+		}
+	})
+
+	t.Run("Outside the grid", func(t *testing.T) {
+		n, ok := NearestNode(g, Coordinate{10, -5})
+		if !ok || n.ID != (Coordinate{1, 0}) {
+			t.Errorf("Expected (1, 0), got %v and %t", n, ok)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		if _, ok := NearestNode(NewUndirectedGraph[Coordinate](), Coordinate{0, 0}); ok {
+			t.Errorf("Expected no nearest node in an empty graph")
+		}
+	})
+}
+
+func TestBuildGridGraphWithBreakable(t *testing.T) {
+	grid := []string{
+		".#.##.",
+		"......",
+	}
+	g, err := BuildGridGraphWithBreakable(grid, CardinalDirections, '.', 5.0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	node := func(x, y int) Node[Coordinate] {
+		return Node[Coordinate]{Coordinate{x, y}}
+	}
+
+	t.Run("Single walls can be broken", func(t *testing.T) {
+		if weight, ok := g.EdgeWeight(node(0, 0), node(2, 0)); !ok || weight != 5.0 {
+			t.Errorf("Expected an edge of weight 5 through the wall, got %f and %t", weight, ok)
+		}
+		// ordinary edges keep their weight
+		if weight, ok := g.EdgeWeight(node(0, 0), node(0, 1)); !ok || weight != 1.0 {
+			t.Errorf("Expected an edge of weight 1, got %f and %t", weight, ok)
+		}
+	})
+
+	t.Run("Two walls in a row can't be broken", func(t *testing.T) {
+		if g.HasEdge(node(2, 0), node(5, 0)) || g.HasNode(node(3, 0)) || g.HasNode(node(4, 0)) {
+			t.Errorf("Expected no way through two walls")
+		}
+	})
+
+	t.Run("Shortcut only when it pays off", func(t *testing.T) {
+		// going around the wall takes 4 steps
+		_, _, cost := g.DijkstraTo(node(0, 0), node(2, 0))
+		if cost != 4.0 {
+			t.Errorf("Expected cost 4.0 around the wall, got %f", cost)
+		}
+		cheap, _ := BuildGridGraphWithBreakable(grid, CardinalDirections, '.', 2.0)
+		path, _, cost := cheap.DijkstraTo(node(0, 0), node(2, 0))
+		if cost != 2.0 || len(path) != 2 {
+			t.Errorf("Expected a shortcut of cost 2.0 through the wall, got %v and %f", path, cost)
+		}
+	})
+}
+
+func TestRenderGrid(t *testing.T) {
+	grid := []string{
+		"#####",
+		"#...#",
+		"#.#.#",
+		"#####",
+	}
+	g, err := BuildGridGraph(grid, CardinalDirections, '.')
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		expected := strings.Join(grid, "\n") + "\n"
+		if got := RenderGrid(g, 5, 4, nil); got != expected {
+			t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+		}
+	})
+
+	t.Run("Marked path", func(t *testing.T) {
+		path, _ := g.BFS(Node[Coordinate]{Coordinate{1, 2}}, Node[Coordinate]{Coordinate{3, 2}})
+		mark := make(map[Coordinate]rune)
+		for _, n := range path {
+			mark[n.ID] = '*'
+		}
+		mark[Coordinate{10, 10}] = '!'
+		expected := "#####\n#***#\n#*#*#\n#####\n"
+		if got := RenderGrid(g, 5, 4, mark); got != expected {
+			t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+		}
+	})
+}
+
+func TestRenderPath(t *testing.T) {
+	grid := []string{
+		"#####",
+		"#S..#",
+		"#.#T#",
+		"#####",
+	}
+	cleaned, start, target, err := FindStartTarget(grid, '.')
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	g, err := BuildGridGraph(cleaned, CardinalDirections, '.')
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Markers stay put", func(t *testing.T) {
+		path, _ := g.BFS(start, target)
+		expected := "#####\n#S**#\n#.#T#\n#####\n"
+		if got := RenderPath(grid, path, '*'); got != expected {
+			t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+		}
+	})
+
+	t.Run("Off the grid", func(t *testing.T) {
+		path := Path[Coordinate]{{Coordinate{1, 2}}, {Coordinate{-1, 0}}, {Coordinate{9, 1}}}
+		expected := "#####\n#S..#\n#o#T#\n#####\n"
+		if got := RenderPath(grid, path, 'o'); got != expected {
+			t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+		}
+	})
+}
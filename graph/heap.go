@@ -0,0 +1,95 @@
+package graph
+
+import "container/heap"
+
+// an entry in the distance queue. it tracks the node, its current
+// tentative distance, and its position in the heap so that its
+// key can be decreased in place
+type distanceItem[K comparable] struct {
+	node     Node[K]
+	distance float64
+	index    int
+}
+
+// indexed min-heap of nodes keyed on their tentative distance.
+// the lookup map lets us find a node's entry to decrease its key.
+// if tieBreak is set, nodes at the same distance come out in the
+// order it gives their IDs, otherwise in no particular order
+type distanceQueue[K comparable] struct {
+	items    []*distanceItem[K]
+	lookup   map[Node[K]]*distanceItem[K]
+	tieBreak func(a, b K) int
+}
+
+// helper to create an empty distance queue
+func newDistanceQueue[K comparable]() *distanceQueue[K] {
+	return &distanceQueue[K]{
+		items:  make([]*distanceItem[K], 0),
+		lookup: make(map[Node[K]]*distanceItem[K]),
+	}
+}
+
+// functions to satisfy heap.Interface. these shouldn't be called
+// directly, use push, pop, and update instead
+func (q *distanceQueue[K]) Len() int { return len(q.items) }
+
+func (q *distanceQueue[K]) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if a.distance == b.distance && q.tieBreak != nil {
+		return q.tieBreak(a.node.ID, b.node.ID) < 0
+	}
+	return a.distance < b.distance
+}
+
+func (q *distanceQueue[K]) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *distanceQueue[K]) Push(x any) {
+	item := x.(*distanceItem[K])
+	item.index = len(q.items)
+	q.items = append(q.items, item)
+	q.lookup[item.node] = item
+}
+
+func (q *distanceQueue[K]) Pop() any {
+	old := q.items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	q.items = old[0 : n-1]
+	delete(q.lookup, item.node)
+	return item
+}
+
+// function to add a node to the queue with the given distance
+func (q *distanceQueue[K]) push(n Node[K], distance float64) {
+	heap.Push(q, &distanceItem[K]{node: n, distance: distance})
+}
+
+// function to remove and return the node with the smallest distance
+func (q *distanceQueue[K]) pop() (Node[K], float64) {
+	item := heap.Pop(q).(*distanceItem[K])
+	return item.node, item.distance
+}
+
+// function to look at the smallest distance in the queue without
+// removing its node. the queue mustn't be empty
+func (q *distanceQueue[K]) peek() float64 {
+	return q.items[0].distance
+}
+
+// function to set the distance of a node. if the node isn't queued
+// yet it gets pushed, otherwise its key is changed in place
+func (q *distanceQueue[K]) update(n Node[K], distance float64) {
+	item, ok := q.lookup[n]
+	if !ok {
+		q.push(n, distance)
+		return
+	}
+	item.distance = distance
+	heap.Fix(q, item.index)
+}
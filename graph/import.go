@@ -0,0 +1,310 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// kinds of tokens in a DOT file
+const (
+	dotEOF = iota
+	dotID
+	dotPunct
+	dotEdgeOp
+)
+
+// a token in a DOT file. quoted IDs are never keywords
+type dotToken struct {
+	kind   int
+	text   string
+	quoted bool
+}
+
+// helper to split DOT source into tokens, dropping comments
+func tokenizeDOT(src string) ([]dotToken, error) {
+	tokens := make([]dotToken, 0)
+	runes := []rune(src)
+	isIDRune := func(r rune) bool {
+		return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			// line comment, skip to the end of the line
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// block comment, skip past its end
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, errors.New("unterminated comment")
+			}
+			i += 2
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '-' || runes[i+1] == '>'):
+			tokens = append(tokens, dotToken{kind: dotEdgeOp, text: string(runes[i : i+2])})
+			i += 2
+		case strings.ContainsRune("{}[];,=", r):
+			tokens = append(tokens, dotToken{kind: dotPunct, text: string(r)})
+			i++
+		case r == '"':
+			// quoted ID, only \" and \\ are unescaped
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i == len(runes) {
+				return nil, errors.New("unterminated quoted string")
+			}
+			i++
+			tokens = append(tokens, dotToken{kind: dotID, text: sb.String(), quoted: true})
+		case isIDRune(r) || r == '-':
+			// plain ID or number, which may be negative
+			start := i
+			i++
+			for i < len(runes) && isIDRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+// a parser walking a list of DOT tokens
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+}
+
+// function to look at the next token without consuming it
+func (p *dotParser) peek() dotToken {
+	if p.pos >= len(p.tokens) {
+		return dotToken{kind: dotEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+// function to consume the next token
+func (p *dotParser) next() dotToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// function to check whether the next token is the given punctuation
+func (p *dotParser) at(punct string) bool {
+	t := p.peek()
+	return t.kind == dotPunct && t.text == punct
+}
+
+// function to check whether the next token is the given keyword
+func (p *dotParser) atKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == dotID && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// function to consume the given punctuation, or fail
+func (p *dotParser) expect(punct string) error {
+	if !p.at(punct) {
+		return fmt.Errorf("expected %q, got %q", punct, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+// function to consume an ID, or fail
+func (p *dotParser) expectID() (string, error) {
+	t := p.next()
+	if t.kind != dotID {
+		return "", fmt.Errorf("expected an ID, got %q", t.text)
+	}
+	return t.text, nil
+}
+
+// function to parse any number of attribute lists like [a=b, c=d]
+func (p *dotParser) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.at("[") {
+		p.next()
+		for !p.at("]") {
+			key, err := p.expectID()
+			if err != nil {
+				return nil, err
+			}
+			value := "true"
+			if p.at("=") {
+				p.next()
+				if value, err = p.expectID(); err != nil {
+					return nil, err
+				}
+			}
+			attrs[key] = value
+			if p.at(",") || p.at(";") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attrs, nil
+}
+
+// helper to find the weight of an edge from its attributes. weight takes
+// precedence over label, and a label that isn't a number is ignored
+func dotWeight(attrs map[string]string) (float64, error) {
+	if value, ok := attrs["weight"]; ok {
+		w, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0.0, fmt.Errorf("invalid edge weight %q", value)
+		}
+		return w, nil
+	}
+	if value, ok := attrs["label"]; ok {
+		if w, err := strconv.ParseFloat(value, 64); err == nil {
+			return w, nil
+		}
+	}
+	return 1.0, nil
+}
+
+// function to read a graph from a DOT file. only a minimal subset of
+// the language is supported:
+//   - a single graph or digraph, optionally strict and named
+//   - node statements, whose attributes are ignored
+//   - edge statements with -- or ->, including chains like a -> b -> c
+//   - edge weights from a numeric weight or label attribute, or 1.0
+//   - graph, node, and edge attribute statements, and key=value
+//     statements, which are all skipped
+//   - comments in //, #, and /* */ style
+//
+// subgraphs and ports are not supported. node IDs are kept as strings.
+// files that mix -- and ->, or use the wrong one for their graph type,
+// are rejected
+func ImportDOT(r io.Reader) (Graph[string], error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenizeDOT(string(src))
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{tokens: tokens}
+
+	// the header decides what kind of graph this is
+	if p.atKeyword("strict") {
+		p.next()
+	}
+	var g Graph[string]
+	var op string
+	switch {
+	case p.atKeyword("digraph"):
+		g, op = NewDirectedGraph[string](), "->"
+	case p.atKeyword("graph"):
+		g, op = NewUndirectedGraph[string](), "--"
+	default:
+		return nil, fmt.Errorf("expected graph or digraph, got %q", p.peek().text)
+	}
+	p.next()
+	// skip the optional graph name
+	if p.peek().kind == dotID {
+		p.next()
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	// the first edge operator seen, to catch files mixing them
+	seenOp := ""
+	for !p.at("}") {
+		t := p.peek()
+		switch {
+		case t.kind == dotEOF:
+			return nil, errors.New("unexpected end of file")
+		case p.at(";"):
+			p.next()
+			continue
+		case t.kind != dotID:
+			return nil, fmt.Errorf("unexpected %q", t.text)
+		case p.atKeyword("subgraph"):
+			return nil, errors.New("subgraphs are not supported")
+		}
+
+		// attribute statements for the graph, nodes, or edges
+		if p.atKeyword("graph") || p.atKeyword("node") || p.atKeyword("edge") {
+			p.next()
+			if _, err := p.attributes(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		id := p.next().text
+		// a key=value statement for the graph
+		if p.at("=") {
+			p.next()
+			if _, err := p.expectID(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// a node, or a chain of edges
+		ids := []string{id}
+		for p.peek().kind == dotEdgeOp {
+			edgeOp := p.next().text
+			if seenOp != "" && edgeOp != seenOp {
+				return nil, errors.New("graph mixes -- and -> edges")
+			}
+			seenOp = edgeOp
+			if edgeOp != op {
+				return nil, fmt.Errorf("edge operator %s doesn't match the graph type", edgeOp)
+			}
+			next, err := p.expectID()
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, next)
+		}
+		attrs, err := p.attributes()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ids) == 1 {
+			g.AddNode(Node[string]{ID: id})
+			continue
+		}
+		w, err := dotWeight(attrs)
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(ids); i++ {
+			g.AddEdge(Node[string]{ID: ids[i-1]}, Node[string]{ID: ids[i]}, w)
+		}
+	}
+	p.next()
+
+	if p.peek().kind != dotEOF {
+		return nil, fmt.Errorf("unexpected %q after the graph", p.peek().text)
+	}
+	return g, nil
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestImportDOT(t *testing.T) {
+	a, b, c := Node[string]{"a"}, Node[string]{"b"}, Node[string]{"c"}
+
+	t.Run("Directed graph with weights", func(t *testing.T) {
+		src := `strict digraph G {
+			rankdir=LR;
+			node [shape=box];
+			// the edges
+			a -> b [weight=2.5];
+			b -> c [label="3"] /* label as weight */
+			c
+			"d e";
+		}`
+		g, err := ImportDOT(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := g.(*DirectedGraph[string]); !ok {
+			t.Fatalf("Expected a directed graph, got %T", g)
+		}
+		if g.NumberOfNodes() != 4 || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected 4 nodes and 2 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if !g.HasNode(Node[string]{"d e"}) {
+			t.Errorf("Expected quoted node to be parsed")
+		}
+		if w := g.Copy().Adjacencies[a.ID][b.ID]; w != 2.5 {
+			t.Errorf("Expected weight 2.5, got %f", w)
+		}
+		if w := g.Copy().Adjacencies[b.ID][c.ID]; w != 3.0 {
+			t.Errorf("Expected weight 3 from label, got %f", w)
+		}
+	})
+
+	t.Run("Undirected edge chain", func(t *testing.T) {
+		g, err := ImportDOT(strings.NewReader("graph { a -- b -- c }"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := g.(*UndirectedGraph[string]); !ok {
+			t.Fatalf("Expected an undirected graph, got %T", g)
+		}
+		if !g.HasEdge(b, a) || !g.HasEdge(c, b) || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected edges a-b and b-c, got %v", g.Edges())
+		}
+		if w := g.Copy().Adjacencies[a.ID][b.ID]; w != 1.0 {
+			t.Errorf("Expected default weight 1.0, got %f", w)
+		}
+	})
+
+	t.Run("Round trip through WriteDOT", func(t *testing.T) {
+		g := NewDirectedGraph[string]()
+		g.AddEdge(a, b, 2.0)
+		g.AddEdge(b, Node[string]{`say "hi"`}, 0.5)
+		g.AddNode(c)
+
+		var buf bytes.Buffer
+		if err := g.WriteDOT(&buf); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		h, err := ImportDOT(&buf)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, h.Copy().Adjacencies) {
+			t.Errorf("Expected %v, got %v", g.Adjacencies, h.Copy().Adjacencies)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		cases := map[string]string{
+			"mixed operators":    "digraph { a -> b; b -- c }",
+			"wrong operator":     "graph { a -> b }",
+			"missing header":     "{ a -> b }",
+			"unterminated graph": "digraph { a -> b",
+			"bad weight":         "digraph { a -> b [weight=x] }",
+			"subgraph":           "digraph { subgraph s { a } }",
+		}
+		for name, src := range cases {
+			if _, err := ImportDOT(strings.NewReader(src)); err == nil {
+				t.Errorf("Expected error for %s", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package graph
+
+import "cmp"
+
+// what a node looks like from the outside, which any node it's mapped to
+// in an isomorphic graph has to match
+type isoSignature struct {
+	out, in int
+	loop    bool
+}
+
+// helper to check whether two graphs have the same structure, with a
+// backtracking search for a mapping of the nodes of g onto the nodes of
+// other that keeps every edge. nodes can only map to nodes with the
+// same degrees, and each new pair has to agree with the edges to the
+// pairs mapped so far, which cuts most dead ends early. if weighted is
+// set, mapped edges also have to weigh the same
+func (g *graphData[K]) isIsomorphic(other *graphData[K], weighted bool) bool {
+	if len(g.Adjacencies) != len(other.Adjacencies) {
+		return false
+	}
+
+	// the degrees have to line up before a mapping is worth looking for
+	signature := func(data *graphData[K], id K) isoSignature {
+		_, loop := data.Adjacencies[id][id]
+		return isoSignature{len(data.Adjacencies[id]), len(data.predecessors[id]), loop}
+	}
+	counts := make(map[isoSignature]int)
+	for id := range g.Adjacencies {
+		counts[signature(g, id)]++
+	}
+	for id := range other.Adjacencies {
+		counts[signature(other, id)]--
+	}
+	for _, c := range counts {
+		if c != 0 {
+			return false
+		}
+	}
+
+	// the nodes of other that each node of g could map to, sorted so the
+	// search runs the same way every time
+	candidates := make(map[K][]K, len(g.Adjacencies))
+	for _, v := range sortedNodes(other.Nodes()) {
+		sig := signature(other, v.ID)
+		for id := range g.Adjacencies {
+			if signature(g, id) == sig {
+				candidates[id] = append(candidates[id], v.ID)
+			}
+		}
+	}
+
+	// map nodes with the most edges to nodes already placed first, so
+	// every choice is checked against as much as possible
+	order := make([]K, 0, len(g.Adjacencies))
+	placed := make(map[K]bool, len(g.Adjacencies))
+	for len(order) < len(g.Adjacencies) {
+		var best K
+		bestLinks, bestDegree, found := -1, -1, false
+		for _, n := range sortedNodes(g.Nodes()) {
+			if placed[n.ID] {
+				continue
+			}
+			links := 0
+			for v := range g.Adjacencies[n.ID] {
+				if placed[v] {
+					links++
+				}
+			}
+			for v := range g.predecessors[n.ID] {
+				if placed[v] {
+					links++
+				}
+			}
+			degree := len(g.Adjacencies[n.ID]) + len(g.predecessors[n.ID])
+			if !found || cmp.Or(cmp.Compare(links, bestLinks), cmp.Compare(degree, bestDegree)) > 0 {
+				best, bestLinks, bestDegree, found = n.ID, links, degree, true
+			}
+		}
+		order = append(order, best)
+		placed[best] = true
+	}
+
+	// helper to check that an edge is in g exactly when its image is in
+	// other, with the same weight if those count
+	sameEdge := func(x, u, y, v K) bool {
+		wg, inG := g.Adjacencies[x][u]
+		wo, inOther := other.Adjacencies[y][v]
+		return inG == inOther && (!weighted || !inG || wg == wo)
+	}
+	// helper to check that mapping u to v agrees with the pairs so far
+	mapped := make(map[K]K, len(order))
+	used := make(map[K]bool, len(order))
+	consistent := func(u, v K) bool {
+		if !sameEdge(u, u, v, v) {
+			return false
+		}
+		for x, y := range mapped {
+			if !sameEdge(x, u, y, v) || !sameEdge(u, x, v, y) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// backtrack with an explicit stack. next holds the index of the next
+	// candidate to try for the node at each depth
+	next := make([]int, len(order))
+	depth := 0
+	for depth >= 0 {
+		if depth == len(order) {
+			return true
+		}
+		u := order[depth]
+		// drop the choice made last time around at this depth
+		if v, ok := mapped[u]; ok {
+			delete(mapped, u)
+			delete(used, v)
+		}
+		found := false
+		for next[depth] < len(candidates[u]) {
+			v := candidates[u][next[depth]]
+			next[depth]++
+			if !used[v] && consistent(u, v) {
+				mapped[u], used[v] = v, true
+				found = true
+				break
+			}
+		}
+		if found {
+			depth++
+			if depth < len(order) {
+				next[depth] = 0
+			}
+		} else {
+			depth--
+		}
+	}
+	return false
+}
+
+// function to check whether two directed graphs have the same structure,
+// ignoring node IDs and edge weights. exact, but the search can take
+// exponential time on large graphs with lots of symmetry, so it's meant
+// for small ones
+func (g *DirectedGraph[K]) IsIsomorphic(other *DirectedGraph[K]) bool {
+	return g.isIsomorphic(&other.graphData, false)
+}
+
+// like IsIsomorphic, but edges also have to weigh the same
+func (g *DirectedGraph[K]) IsIsomorphicWeighted(other *DirectedGraph[K]) bool {
+	return g.isIsomorphic(&other.graphData, true)
+}
+
+// function to check whether two undirected graphs have the same
+// structure, ignoring node IDs and edge weights. exact, but the search
+// can take exponential time on large graphs with lots of symmetry, so
+// it's meant for small ones
+func (g *UndirectedGraph[K]) IsIsomorphic(other *UndirectedGraph[K]) bool {
+	return g.isIsomorphic(&other.graphData, false)
+}
+
+// like IsIsomorphic, but edges also have to weigh the same
+func (g *UndirectedGraph[K]) IsIsomorphicWeighted(other *UndirectedGraph[K]) bool {
+	return g.isIsomorphic(&other.graphData, true)
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestIsIsomorphic(t *testing.T) {
+	t.Run("Relabeled triangles", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		h := CycleGraph([]int{7, 9, 8})
+		if !g.IsIsomorphic(h) {
+			t.Errorf("Expected triangles to be isomorphic")
+		}
+	})
+
+	t.Run("Triangle and path", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		h := PathGraph([]int{1, 2, 3})
+		if g.IsIsomorphic(h) || h.IsIsomorphic(g) {
+			t.Errorf("Expected triangle and path not to be isomorphic")
+		}
+	})
+
+	t.Run("Same degrees, different shape", func(t *testing.T) {
+		// a hexagon and two triangles have the same degrees everywhere
+		g := CycleGraph([]int{1, 2, 3, 4, 5, 6})
+		h := CycleGraph([]int{1, 2, 3})
+		for _, e := range CycleGraph([]int{4, 5, 6}).Edges() {
+			h.AddEdge(e.u, e.v, e.weight)
+		}
+		if g.IsIsomorphic(h) {
+			t.Errorf("Expected hexagon and two triangles not to be isomorphic")
+		}
+	})
+
+	t.Run("Shuffled random graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(9, 10))
+		for range 20 {
+			g := ErdosRenyiGraph(10, 0.3, rng, nil)
+			perm := rng.Perm(10)
+			h := NewUndirectedGraph[int]()
+			for _, n := range g.Nodes() {
+				h.AddNode(Node[int]{perm[n.ID]})
+			}
+			for _, e := range g.Edges() {
+				h.AddEdge(Node[int]{perm[e.u.ID]}, Node[int]{perm[e.v.ID]}, e.weight)
+			}
+			if !g.IsIsomorphic(h) {
+				t.Errorf("Expected shuffled graph to be isomorphic to %v", g.Edges())
+			}
+		}
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		h := NewDirectedGraph[int]()
+		h.AddEdge(Node[int]{3}, Node[int]{2}, 1.0)
+		h.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+		if !g.IsIsomorphic(h) {
+			t.Errorf("Expected reversed chain to be isomorphic")
+		}
+
+		// a node fanning out is not the same as one fanning in
+		out := NewDirectedGraph[int]()
+		out.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		out.AddEdge(Node[int]{1}, Node[int]{3}, 1.0)
+		in := NewDirectedGraph[int]()
+		in.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+		in.AddEdge(Node[int]{3}, Node[int]{1}, 1.0)
+		if out.IsIsomorphic(in) {
+			t.Errorf("Expected fan-out and fan-in not to be isomorphic")
+		}
+	})
+
+	t.Run("Weights", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{2}, 2.0)
+		h := PathGraph([]int{1, 2, 3})
+		h.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 2.0)
+		if !g.IsIsomorphicWeighted(h) {
+			t.Errorf("Expected mirrored weighted paths to be isomorphic")
+		}
+		h.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 3.0)
+		if g.IsIsomorphicWeighted(h) || !g.IsIsomorphic(h) {
+			t.Errorf("Expected weights to only matter when asked for")
+		}
+	})
+
+	t.Run("Self-loops and empty graphs", func(t *testing.T) {
+		g := PathGraph([]int{1, 2})
+		g.AddEdge(Node[int]{1}, Node[int]{1}, 1.0)
+		h := PathGraph([]int{1, 2})
+		h.AddEdge(Node[int]{2}, Node[int]{2}, 1.0)
+		if !g.IsIsomorphic(h) {
+			t.Errorf("Expected self-loops at mirrored nodes to be isomorphic")
+		}
+		if !NewUndirectedGraph[int]().IsIsomorphic(NewUndirectedGraph[int]()) {
+			t.Errorf("Expected empty graphs to be isomorphic")
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// on-the-wire representation of an edge
+type jsonEdge[K comparable] struct {
+	U      K       `json:"u"`
+	V      K       `json:"v"`
+	Weight float64 `json:"weight"`
+}
+
+// on-the-wire representation of a graph. the type field records
+// whether the graph is directed or undirected
+type jsonGraph[K comparable] struct {
+	Type  string        `json:"type"`
+	Nodes []K           `json:"nodes"`
+	Edges []jsonEdge[K] `json:"edges"`
+}
+
+// names for the graph types used in the type field
+const (
+	jsonDirected   = "directed"
+	jsonUndirected = "undirected"
+)
+
+// helper to build the wire representation from a list of edges
+func (g *graphData[K]) toJSON(kind string, edges []Edge[K]) jsonGraph[K] {
+	jg := jsonGraph[K]{
+		Type:  kind,
+		Nodes: make([]K, 0, len(g.Adjacencies)),
+		Edges: make([]jsonEdge[K], 0, len(edges)),
+	}
+	for n := range g.NodesSeq() {
+		jg.Nodes = append(jg.Nodes, n.ID)
+	}
+	for _, e := range edges {
+		jg.Edges = append(jg.Edges, jsonEdge[K]{U: e.u.ID, V: e.v.ID, Weight: e.weight})
+	}
+	return jg
+}
+
+// helper to decode the wire representation, check that it's of the
+// expected type, and return it for the caller to add the edges
+func decodeJSON[K comparable](data []byte, kind string) (jsonGraph[K], error) {
+	var jg jsonGraph[K]
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return jg, err
+	}
+	if jg.Type != kind {
+		return jg, fmt.Errorf("cannot decode %q graph into %s graph", jg.Type, kind)
+	}
+	return jg, nil
+}
+
+// encode a directed graph as JSON
+func (g *DirectedGraph[K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.toJSON(jsonDirected, g.Edges()))
+}
+
+// decode a directed graph from JSON, replacing the graph's contents
+func (g *DirectedGraph[K]) UnmarshalJSON(data []byte) error {
+	jg, err := decodeJSON[K](data, jsonDirected)
+	if err != nil {
+		return err
+	}
+	// build the decoded graph on the side and swap it in, so the
+	// settings, snapshots, and observers of the graph are kept
+	fresh := &DirectedGraph[K]{graphData: newGraphData[K]()}
+	fresh.degreeHint, fresh.noSelfLoops = g.degreeHint, g.noSelfLoops
+	for _, id := range jg.Nodes {
+		fresh.AddNode(Node[K]{ID: id})
+	}
+	for _, e := range jg.Edges {
+		fresh.AddEdge(Node[K]{ID: e.U}, Node[K]{ID: e.V}, e.Weight)
+	}
+	g.replace(fresh.graphData)
+	return nil
+}
+
+// encode an undirected graph as JSON
+func (g *UndirectedGraph[K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.toJSON(jsonUndirected, g.Edges()))
+}
+
+// decode an undirected graph from JSON, replacing the graph's contents
+func (g *UndirectedGraph[K]) UnmarshalJSON(data []byte) error {
+	jg, err := decodeJSON[K](data, jsonUndirected)
+	if err != nil {
+		return err
+	}
+	// build the decoded graph on the side and swap it in, so the
+	// settings, snapshots, and observers of the graph are kept
+	fresh := &UndirectedGraph[K]{graphData: newGraphData[K]()}
+	fresh.degreeHint, fresh.noSelfLoops = g.degreeHint, g.noSelfLoops
+	for _, id := range jg.Nodes {
+		fresh.AddNode(Node[K]{ID: id})
+	}
+	for _, e := range jg.Edges {
+		fresh.AddEdge(Node[K]{ID: e.U}, Node[K]{ID: e.V}, e.Weight)
+	}
+	g.replace(fresh.graphData)
+	return nil
+}
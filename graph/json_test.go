@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+// helper to compare two adjacency structures
+func sameAdjacencies[K comparable](a, b map[K]map[K]float64) bool {
+	return maps.EqualFunc(a, b, func(x, y map[K]float64) bool {
+		return maps.Equal(x, y)
+	})
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Directed graph round trip", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.AddEdge(v, w, 0.1)
+		g.AddNode(x)
+
+		data, err := json.Marshal(g)
+		if err != nil {
+			t.Fatalf("Expected no error marshaling, got %v", err)
+		}
+		h := NewDirectedGraph[int]()
+		if err := json.Unmarshal(data, h); err != nil {
+			t.Fatalf("Expected no error unmarshaling, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, h.Adjacencies) {
+			t.Errorf("Expected %v after round trip, got %v", g.Adjacencies, h.Adjacencies)
+		}
+	})
+
+	t.Run("Undirected graph round trip", func(t *testing.T) {
+		g := NewUndirectedGraph[string]()
+		g.AddEdge(Node[string]{"a"}, Node[string]{"b"}, 2.25)
+		g.AddEdge(Node[string]{"b"}, Node[string]{"b"}, 3.0)
+		g.AddNode(Node[string]{"c"})
+
+		data, err := json.Marshal(g)
+		if err != nil {
+			t.Fatalf("Expected no error marshaling, got %v", err)
+		}
+		// decode into a zero value graph to make sure that works too
+		var h UndirectedGraph[string]
+		if err := json.Unmarshal(data, &h); err != nil {
+			t.Fatalf("Expected no error unmarshaling, got %v", err)
+		}
+		if !sameAdjacencies(g.Adjacencies, h.Adjacencies) {
+			t.Errorf("Expected %v after round trip, got %v", g.Adjacencies, h.Adjacencies)
+		}
+	})
+
+	t.Run("Graph type mismatch", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+
+		data, _ := json.Marshal(g)
+		h := NewUndirectedGraph[int]()
+		if err := json.Unmarshal(data, h); err == nil {
+			t.Errorf("Expected error decoding directed graph into undirected graph")
+		}
+	})
+
+	t.Run("Decoding keeps settings, snapshots, and observers", func(t *testing.T) {
+		src := NewUndirectedGraph[int]()
+		src.AddEdge(u, v, 1.0)
+		src.AddEdge(w, w, 2.0)
+		data, _ := json.Marshal(src)
+
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(x, u, 4.0)
+		g.DisallowSelfLoops()
+		added := 0
+		g.OnAddNode(func(Node[int]) { added++ })
+		s := g.Snapshot()
+
+		if err := json.Unmarshal(data, g); err != nil {
+			t.Fatalf("Expected no error unmarshaling, got %v", err)
+		}
+		if g.HasSelfLoop(w) || !g.HasEdge(u, v) {
+			t.Errorf("Expected the decoded edges without the self-loop, got %v", g.Edges())
+		}
+		g.AddNode(Node[int]{9})
+		if added != 1 {
+			t.Errorf("Expected the observer to still be registered, got %d calls", added)
+		}
+		if err := g.Restore(s); err != nil {
+			t.Fatalf("Expected no error restoring, got %v", err)
+		}
+		if !g.HasEdge(x, u) || g.HasNode(v) || g.NumberOfNodes() != 2 {
+			t.Errorf("Expected the graph from before decoding, got %v", g.Edges())
+		}
+	})
+}
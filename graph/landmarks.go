@@ -0,0 +1,130 @@
+package graph
+
+import "math"
+
+// precomputed distances to and from a handful of landmark nodes, used
+// to speed up repeated shortest path queries on the same graph. the
+// index describes the graph as it was when it was prepared, so it has
+// to be prepared again after the graph changes
+type LandmarkIndex[K comparable] struct {
+	landmarks []Node[K]
+	// distances from each landmark to every node
+	from []Distances[K]
+	// distances from every node to each landmark
+	to []Distances[K]
+}
+
+// function to return the nodes picked as landmarks
+func (index *LandmarkIndex[K]) Landmarks() []Node[K] {
+	return index.landmarks
+}
+
+// function to prepare a landmark index for QueryLandmarks, using up to
+// k landmarks. landmarks are picked far apart from each other: the first
+// is the node with the smallest ID, and every further one is the node
+// furthest from those picked so far.
+//
+// preparing the index runs Dijkstra twice per landmark and keeps two
+// distances per landmark for every node, so it costs about as much as
+// 2k shortest path queries and O(kV) memory. in return, every query
+// only explores the nodes that look like they lead towards the target,
+// which on large grids and road-like graphs is usually a small fraction
+// of what Dijkstra visits. more landmarks give better guidance at the
+// price of more work per visited node, a handful is usually enough
+func (g *graphData[K]) PrepareLandmarks(k int) *LandmarkIndex[K] {
+	index := &LandmarkIndex[K]{}
+	nodes := sortedNodes(g.Nodes())
+	if len(nodes) == 0 || k < 1 {
+		return index
+	}
+
+	// the predecessors are the edges turned around, so Dijkstra over
+	// them gives the distances towards a node
+	reversed := &graphData[K]{Adjacencies: g.predecessors}
+
+	// how far every node is from the closest landmark so far
+	closest := make(Distances[K], len(nodes))
+	for _, n := range nodes {
+		closest[n] = math.Inf(1)
+	}
+
+	landmark := nodes[0]
+	for len(index.landmarks) < min(k, len(nodes)) {
+		from, _ := g.Dijkstra(landmark)
+		to, _ := reversed.Dijkstra(landmark)
+		index.landmarks = append(index.landmarks, landmark)
+		index.from = append(index.from, from)
+		index.to = append(index.to, to)
+
+		// pick the node furthest from all landmarks as the next one.
+		// nodes the landmarks can't reach at all count as furthest
+		closest[landmark] = -1.0
+		next := -1
+		for i, n := range nodes {
+			closest[n] = min(closest[n], from[n])
+			if closest[n] >= 0.0 && (next < 0 || closest[n] > closest[nodes[next]]) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		landmark = nodes[next]
+	}
+	return index
+}
+
+// helper to estimate the distance from n to the target using the
+// triangle inequality on the landmark distances. the estimate never
+// overshoots, which keeps the search exact
+func (index *LandmarkIndex[K]) estimate(n, target Node[K]) float64 {
+	best := 0.0
+	for i := range index.landmarks {
+		from, to := index.from[i], index.to[i]
+		// going via the landmark can't be shorter than the direct way
+		if d := to[n] - to[target]; !math.IsNaN(d) && !math.IsInf(to[target], 1) && d > best {
+			best = d
+		}
+		if d := from[target] - from[n]; !math.IsNaN(d) && !math.IsInf(from[n], 1) && d > best {
+			best = d
+		}
+	}
+	return best
+}
+
+// calculate the shortest path from a given node to a given node with
+// A*, guided by the distances in a landmark index prepared for this
+// graph with PrepareLandmarks. follows the same contract as DijkstraTo
+func (g *graphData[K]) QueryLandmarks(index *LandmarkIndex[K], start, target Node[K]) (Path[K], int, float64) {
+	// if we're already there...
+	if start == target {
+		return Path[K]{target}, 1, 0.0
+	}
+
+	distances := Distances[K]{start: 0.0}
+	previous := Paths[K]{start: start}
+	// nodes are queued by their distance plus the estimate of how far
+	// they still are from the target
+	queue := newDistanceQueue[K]()
+	queue.push(start, index.estimate(start, target))
+
+	for queue.Len() > 0 {
+		current, _ := queue.pop()
+		if current == target {
+			path, _ := ReconstructPath(previous, start, target)
+			return path, len(path), distances[target]
+		}
+
+		for neighbor, weight := range g.successorsSeq(current) {
+			alternative := distances[current] + weight
+			if d, ok := distances[neighbor]; ok && alternative >= d {
+				continue
+			}
+			distances[neighbor] = alternative
+			previous[neighbor] = current
+			queue.update(neighbor, alternative+index.estimate(neighbor, target))
+		}
+	}
+
+	return Path[K]{}, 0, math.Inf(1)
+}
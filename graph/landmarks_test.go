@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLandmarks(t *testing.T) {
+	t.Run("Landmarks are spread out", func(t *testing.T) {
+		g := GridGraph(10, 10)
+		index := g.PrepareLandmarks(2)
+		landmarks := index.Landmarks()
+		if len(landmarks) != 2 {
+			t.Fatalf("Expected 2 landmarks, got %v", landmarks)
+		}
+		// the first corner, then the one furthest from it
+		if landmarks[0].ID != (Coordinate{0, 0}) || landmarks[1].ID != (Coordinate{9, 9}) {
+			t.Errorf("Expected opposite corners as landmarks, got %v", landmarks)
+		}
+	})
+
+	t.Run("Matches Dijkstra on a grid", func(t *testing.T) {
+		g := buildBenchmarkGrid(20, 20)
+		index := g.PrepareLandmarks(4)
+		for _, pair := range [][2]int{{0, 399}, {15, 300}, {399, 0}, {7, 7}, {210, 211}} {
+			start, target := Node[int]{pair[0]}, Node[int]{pair[1]}
+			_, _, expected := g.DijkstraTo(start, target)
+			path, l, cost := g.QueryLandmarks(index, start, target)
+			if cost != expected || l != len(path) || path[0] != start || path[l-1] != target {
+				t.Errorf("Expected cost %f from %v to %v, got %v and %f", expected, start, target, path, cost)
+			}
+		}
+	})
+
+	t.Run("Matches Dijkstra on random directed graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(5, 6))
+		for range 20 {
+			g := NewDirectedGraph[int]()
+			for u := range 30 {
+				g.AddNode(Node[int]{u})
+				for v := range 30 {
+					if u != v && rng.Float64() < 0.08 {
+						g.AddEdge(Node[int]{u}, Node[int]{v}, float64(1+rng.IntN(9)))
+					}
+				}
+			}
+			index := g.PrepareLandmarks(3)
+			for target := range 30 {
+				_, expectedL, expected := g.DijkstraTo(Node[int]{0}, Node[int]{target})
+				path, l, cost := g.QueryLandmarks(index, Node[int]{0}, Node[int]{target})
+				if cost != expected || (l == 0) != (expectedL == 0) {
+					t.Fatalf("Expected cost %f to %d, got %f with path %v", expected, target, cost, path)
+				}
+				if weight, ok := path.PathWeight(g); l > 0 && (!ok || weight != cost) {
+					t.Fatalf("Expected valid path to %d costing %f, got %v", target, cost, path)
+				}
+			}
+		}
+	})
+
+	t.Run("Unreachable target", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.AddNode(Node[int]{4})
+		index := g.PrepareLandmarks(2)
+		path, l, cost := g.QueryLandmarks(index, Node[int]{1}, Node[int]{4})
+		if path == nil || len(path) != 0 || l != 0 || !math.IsInf(cost, 1) {
+			t.Errorf("Expected empty path, length 0, and infinite cost, got %v, %d, and %f", path, l, cost)
+		}
+	})
+}
+
+func BenchmarkQueryLandmarks(b *testing.B) {
+	g := buildBenchmarkGrid(500, 500)
+	index := g.PrepareLandmarks(4)
+	start, target := Node[int]{0}, Node[int]{250*500 + 250}
+	for b.Loop() {
+		g.QueryLandmarks(index, start, target)
+	}
+}
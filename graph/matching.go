@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// function to find a maximum matching of a bipartite graph, the largest
+// set of edges where no two share a node. uses Hopcroft-Karp, which
+// finds many shortest augmenting paths per round and needs O(E sqrt V)
+// time overall. the matching maps every matched node to its partner,
+// so each matched edge shows up from both ends, and the size is the
+// number of edges. graphs that aren't bipartite, see IsBipartite, give
+// an empty matching of size 0
+func (g *UndirectedGraph[K]) MaximumBipartiteMatching() (map[Node[K]]Node[K], int) {
+	matching := make(map[Node[K]]Node[K])
+	bipartite, classes := g.IsBipartite()
+	if !bipartite {
+		return matching, 0
+	}
+
+	// match from the left side. neighbors are sorted so the result is
+	// the same from run to run
+	left := sortedNodes(classes[0])
+	neighbors := make(map[Node[K]][]Node[K], len(left))
+	for _, u := range left {
+		neighbors[u] = sortedNodes(g.Successors(u))
+	}
+
+	// distance of the left nodes in the layered graph of a round
+	dist := make(map[Node[K]]float64, len(left))
+	inf := math.Inf(1)
+
+	// helper to layer the graph by BFS from the free left nodes. returns
+	// whether any augmenting path exists
+	layer := func() bool {
+		queue := make(Queue[K], 0)
+		for _, u := range left {
+			if _, ok := matching[u]; ok {
+				dist[u] = inf
+			} else {
+				dist[u] = 0
+				queue = append(queue, u)
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range neighbors[u] {
+				w, ok := matching[v]
+				if !ok {
+					// a free node on the right ends an augmenting path
+					found = true
+				} else if dist[w] == inf {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	// helper to look for an augmenting path from a free left node along
+	// the layers, and flip the matching along it if there is one
+	augment := func(root Node[K]) bool {
+		// a frame on the explicit stack, with the index of the next
+		// neighbor to try
+		type frame struct {
+			u    Node[K]
+			next int
+		}
+		stack := []frame{{root, 0}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(neighbors[top.u]) {
+				// dead end, don't come back here this round
+				dist[top.u] = inf
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := neighbors[top.u][top.next]
+			top.next++
+			w, ok := matching[v]
+			if !ok {
+				// reached a free node. every frame on the stack steps
+				// to the neighbor it last tried, match along that
+				for _, f := range stack {
+					v := neighbors[f.u][f.next-1]
+					matching[f.u], matching[v] = v, f.u
+				}
+				return true
+			}
+			if dist[w] == dist[top.u]+1 {
+				stack = append(stack, frame{w, 0})
+			}
+		}
+		return false
+	}
+
+	size := 0
+	for layer() {
+		for _, u := range left {
+			if _, ok := matching[u]; !ok && augment(u) {
+				size++
+			}
+		}
+	}
+	return matching, size
+}
+
+// function to find a matching of any undirected graph by taking the
+// cheapest edges first, skipping those that touch a node that's already
+// matched. self-loops are ignored. this is only an approximation, the
+// matching is maximal but not always maximum, and its weight isn't
+// always the lowest possible. returns the matching with both ends of
+// every edge mapped to each other, like MaximumBipartiteMatching, and
+// the total weight of the matched edges
+func (g *UndirectedGraph[K]) GreedyMatching() (map[Node[K]]Node[K], float64) {
+	matching := make(map[Node[K]]Node[K])
+
+	// edges are sorted by weight, and by their nodes when weights tie,
+	// so the result is the same from run to run
+	edges := sortedEdges(g.Edges())
+	slices.SortStableFunc(edges, func(a, b Edge[K]) int {
+		return cmp.Compare(a.weight, b.weight)
+	})
+
+	total := 0.0
+	for _, e := range edges {
+		if e.u == e.v {
+			continue
+		}
+		_, uMatched := matching[e.u]
+		_, vMatched := matching[e.v]
+		if uMatched || vMatched {
+			continue
+		}
+		matching[e.u], matching[e.v] = e.v, e.u
+		total += e.weight
+	}
+	return matching, total
+}
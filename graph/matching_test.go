@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"maps"
+	"math/rand/v2"
+	"testing"
+)
+
+// helper to check that a matching only uses edges of the graph, pairs
+// nodes up both ways, and has the given size
+func checkMatching[K comparable](t *testing.T, g *UndirectedGraph[K], matching map[Node[K]]Node[K], size, expected int) {
+	t.Helper()
+	if size != expected || len(matching) != 2*size {
+		t.Errorf("Expected matching of size %d, got %d with %v", expected, size, matching)
+	}
+	for u, v := range matching {
+		if !g.HasEdge(u, v) || matching[v] != u {
+			t.Errorf("Expected %v and %v to be matched by an edge both ways", u, v)
+		}
+	}
+}
+
+func TestMaximumBipartiteMatching(t *testing.T) {
+	t.Run("Greedy choice needs fixing", func(t *testing.T) {
+		// matching 1-4 first blocks 2, so the path has to be rerouted
+		g := NewUndirectedGraph[int]()
+		for _, e := range [][2]int{{1, 4}, {1, 5}, {2, 4}, {3, 5}, {3, 6}} {
+			g.AddEdge(Node[int]{e[0]}, Node[int]{e[1]}, 1.0)
+		}
+		matching, size := g.MaximumBipartiteMatching()
+		checkMatching(t, g, matching, size, 3)
+	})
+
+	t.Run("Unbalanced sides", func(t *testing.T) {
+		g := StarGraph([]int{1, 2, 3, 4})
+		g.AddNode(Node[int]{5})
+		matching, size := g.MaximumBipartiteMatching()
+		checkMatching(t, g, matching, size, 1)
+	})
+
+	t.Run("Matches max flow on random graphs", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(7, 8))
+		for range 30 {
+			g := NewUndirectedGraph[int]()
+			// flow network from a source at -1 to a sink at -2
+			flow := NewDirectedGraph[int]()
+			source, sink := Node[int]{-1}, Node[int]{-2}
+			for u := range 12 {
+				g.AddNode(Node[int]{u})
+				g.AddNode(Node[int]{100 + u})
+				flow.AddEdge(source, Node[int]{u}, 1.0)
+				flow.AddEdge(Node[int]{100 + u}, sink, 1.0)
+				for v := range 12 {
+					if rng.Float64() < 0.15 {
+						g.AddEdge(Node[int]{u}, Node[int]{100 + v}, 1.0)
+						flow.AddEdge(Node[int]{u}, Node[int]{100 + v}, 1.0)
+					}
+				}
+			}
+			matching, size := g.MaximumBipartiteMatching()
+			checkMatching(t, g, matching, size, int(flow.MaxFlow(source, sink)))
+		}
+	})
+
+	t.Run("Not bipartite", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		matching, size := g.MaximumBipartiteMatching()
+		if matching == nil || len(matching) != 0 || size != 0 {
+			t.Errorf("Expected empty matching, got %v and %d", matching, size)
+		}
+	})
+}
+
+func TestGreedyMatching(t *testing.T) {
+	t.Run("Cheapest edges first", func(t *testing.T) {
+		// triangle 1-2-3 plus a pendant 4 on 3, not bipartite
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 2.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 3.0)
+		g.AddEdge(Node[int]{3}, Node[int]{4}, 5.0)
+		g.AddEdge(Node[int]{4}, Node[int]{4}, 0.5)
+		matching, total := g.GreedyMatching()
+		expected := map[Node[int]]Node[int]{{1}: {2}, {2}: {1}, {3}: {4}, {4}: {3}}
+		if !maps.Equal(matching, expected) {
+			t.Errorf("Expected %v, got %v", expected, matching)
+		}
+		if total != 6.0 {
+			t.Errorf("Expected total weight 6, got %f", total)
+		}
+	})
+
+	t.Run("Approximate", func(t *testing.T) {
+		// the cheap middle edge blocks both outer ones
+		g := PathGraph([]int{1, 2, 3, 4})
+		g.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 0.5)
+		matching, total := g.GreedyMatching()
+		if len(matching) != 2 || matching[Node[int]{2}] != (Node[int]{3}) || total != 0.5 {
+			t.Errorf("Expected 2-3 matched with weight 0.5, got %v and %f", matching, total)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		matching, total := NewUndirectedGraph[int]().GreedyMatching()
+		if matching == nil || len(matching) != 0 || total != 0 {
+			t.Errorf("Expected empty matching, got %v and %f", matching, total)
+		}
+	})
+}
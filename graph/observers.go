@@ -0,0 +1,88 @@
+package graph
+
+// the callbacks registered on a graph, see OnAddNode
+type observers[K comparable] struct {
+	addNode    []func(n Node[K])
+	removeNode []func(n Node[K])
+	addEdge    []func(u, v Node[K], w float64)
+	removeEdge []func(u, v Node[K], w float64)
+}
+
+// the observers of a graph are told about nodes and edges being added
+// and removed, after the fact, for things like renderers that follow a
+// graph as it changes. any number of them can be registered, and they
+// are called in the order they were registered. callbacks only fire
+// when something changed, so adding a node that's already there or
+// removing a missing edge doesn't call them.
+//
+// they fire for AddNode, AddEdge, RemoveEdge, RemoveNode, and the
+// functions built on those, like AddEdgesFrom, as well as for
+// RemoveSelfLoops and the edges ContractEdge rewires. removing a node
+// takes its edges along without a callback for each of them, and Clear
+// counts as removing every node. changes made in other ways, like
+// SetEdgeWeight, MapWeights, Relabel, Restore, UnmarshalJSON, or
+// writing Adjacencies directly, don't fire them. copies of a graph start without observers
+
+// function to register a callback for nodes added to the graph
+func (g *graphData[K]) OnAddNode(f func(n Node[K])) {
+	g.watchers().addNode = append(g.watchers().addNode, f)
+}
+
+// function to register a callback for nodes removed from the graph
+func (g *graphData[K]) OnRemoveNode(f func(n Node[K])) {
+	g.watchers().removeNode = append(g.watchers().removeNode, f)
+}
+
+// function to register a callback for edges added to the graph, or
+// given a new weight by AddEdge. undirected edges are reported once,
+// with their nodes in the order they were passed to AddEdge
+func (g *graphData[K]) OnAddEdge(f func(u, v Node[K], w float64)) {
+	g.watchers().addEdge = append(g.watchers().addEdge, f)
+}
+
+// function to register a callback for edges removed from the graph,
+// with the weight they had
+func (g *graphData[K]) OnRemoveEdge(f func(u, v Node[K], w float64)) {
+	g.watchers().removeEdge = append(g.watchers().removeEdge, f)
+}
+
+// helper to get the observers of the graph, setting them up on first use
+func (g *graphData[K]) watchers() *observers[K] {
+	if g.observers == nil {
+		g.observers = &observers[K]{}
+	}
+	return g.observers
+}
+
+// helpers to call the observers of each kind of change
+func (g *graphData[K]) notifyAddNode(n Node[K]) {
+	if g.observers != nil {
+		for _, f := range g.observers.addNode {
+			f(n)
+		}
+	}
+}
+
+func (g *graphData[K]) notifyRemoveNode(n Node[K]) {
+	if g.observers != nil {
+		for _, f := range g.observers.removeNode {
+			f(n)
+		}
+	}
+}
+
+func (g *graphData[K]) notifyAddEdge(u, v Node[K], w float64) {
+	if g.observers != nil {
+		for _, f := range g.observers.addEdge {
+			f(u, v, w)
+		}
+	}
+}
+
+func (g *graphData[K]) notifyRemoveEdge(u, v Node[K], w float64) {
+	if g.observers != nil {
+		for _, f := range g.observers.removeEdge {
+			f(u, v, w)
+		}
+	}
+}
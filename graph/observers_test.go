@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestObservers(t *testing.T) {
+	// helper to set up a graph that logs every change it's told about
+	watch := func(g interface {
+		OnAddNode(func(Node[int]))
+		OnRemoveNode(func(Node[int]))
+		OnAddEdge(func(u, v Node[int], w float64))
+		OnRemoveEdge(func(u, v Node[int], w float64))
+	}) *[]string {
+		events := make([]string, 0)
+		g.OnAddNode(func(n Node[int]) { events = append(events, fmt.Sprintf("+%d", n.ID)) })
+		g.OnRemoveNode(func(n Node[int]) { events = append(events, fmt.Sprintf("-%d", n.ID)) })
+		g.OnAddEdge(func(u, v Node[int], w float64) { events = append(events, fmt.Sprintf("+%d-%d:%g", u.ID, v.ID, w)) })
+		g.OnRemoveEdge(func(u, v Node[int], w float64) { events = append(events, fmt.Sprintf("-%d-%d:%g", u.ID, v.ID, w)) })
+		return &events
+	}
+
+	t.Run("Undirected", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		events := watch(g)
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.5)
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 1.5)
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 2.0)
+		g.AddNode(Node[int]{1})
+		g.RemoveEdge(Node[int]{2}, Node[int]{1})
+		g.RemoveEdge(Node[int]{2}, Node[int]{1})
+		g.RemoveNode(Node[int]{2})
+		g.RemoveNode(Node[int]{2})
+		expected := []string{"+1", "+2", "+1-2:1.5", "+2-1:2", "-2-1:2", "-2"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddNode(Node[int]{1})
+		events := watch(g)
+		g.AddEdgesFrom([]Edge[int]{NewEdge(Node[int]{1}, Node[int]{2}, 1.0), NewEdge(Node[int]{2}, Node[int]{1}, 1.0)})
+		g.RemoveEdgesFrom([]Edge[int]{NewEdge(Node[int]{1}, Node[int]{2}, 1.0), NewEdge(Node[int]{1}, Node[int]{3}, 1.0)})
+		expected := []string{"+2", "+1-2:1", "+2-1:1", "-1-2:1"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("Self-loops and contraction", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{3}, Node[int]{3}, 4.0)
+		events := watch(g)
+		g.RemoveSelfLoops()
+		g.ContractEdge(Node[int]{1}, Node[int]{2})
+		expected := []string{"-3-3:4", "-2", "+1-3:1"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+
+		d := NewDirectedGraph[int]()
+		d.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		d.AddEdge(Node[int]{2}, Node[int]{3}, 2.0)
+		d.AddEdge(Node[int]{3}, Node[int]{2}, 3.0)
+		events = watch(d)
+		d.ContractEdge(Node[int]{1}, Node[int]{2})
+		expected = []string{"-2", "+1-3:2", "+3-1:3"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("Registration order", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		order := make([]int, 0)
+		for i := range 3 {
+			g.OnAddNode(func(n Node[int]) { order = append(order, i) })
+		}
+		g.AddNode(Node[int]{1})
+		if !slices.Equal(order, []int{0, 1, 2}) {
+			t.Errorf("Expected observers in registration order, got %v", order)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		g := PathGraph([]int{1, 2})
+		removed := make([]Node[int], 0)
+		left := make([]int, 0)
+		g.OnRemoveNode(func(n Node[int]) {
+			removed = append(removed, n)
+			left = append(left, g.NumberOfNodes())
+		})
+		s := g.Snapshot()
+		g.Clear()
+		if len(removed) != 2 || !slices.Equal(left, []int{0, 0}) {
+			t.Errorf("Expected both nodes to be reported once the graph was cleared, got %v with %v left", removed, left)
+		}
+		// observers survive clearing and restoring a snapshot
+		g.Restore(s)
+		g.RemoveNode(Node[int]{1})
+		if len(removed) != 3 {
+			t.Errorf("Expected observers to still be registered, got %v", removed)
+		}
+	})
+}
@@ -0,0 +1,401 @@
+package graph
+
+import (
+	"fmt"
+	"maps"
+	"math"
+)
+
+// helper to combine the nodes and edges of two graphs. edges present
+// in both graphs get the weight merge(ours, theirs). if merge is nil,
+// the weight from the other graph wins
+func (g *graphData[K]) union(other *graphData[K], merge func(a, b float64) float64) graphData[K] {
+	result := newGraphData[K]()
+	for _, source := range []*graphData[K]{g, other} {
+		for u := range source.NodesSeq() {
+			result.AddNode(u)
+			for v, w := range source.successorsSeq(u) {
+				result.AddNode(v)
+				// collision on an edge already copied from this graph
+				if existing, ok := result.Adjacencies[u.ID][v.ID]; ok && source == other && merge != nil {
+					w = merge(existing, w)
+				}
+				result.setEdge(u, v, w)
+			}
+		}
+	}
+	return result
+}
+
+// helper to keep only the nodes and edges present in both graphs.
+// weights are taken from this graph
+func (g *graphData[K]) intersection(other *graphData[K]) graphData[K] {
+	result := newGraphData[K]()
+	for u := range g.NodesSeq() {
+		if !other.HasNode(u) {
+			continue
+		}
+		result.AddNode(u)
+		for v, w := range g.successorsSeq(u) {
+			if other.HasEdge(u, v) {
+				result.AddNode(v)
+				result.setEdge(u, v, w)
+			}
+		}
+	}
+	return result
+}
+
+// helper to remove the edges of another graph from this one.
+// all of this graph's nodes are kept
+func (g *graphData[K]) difference(other *graphData[K]) graphData[K] {
+	result := newGraphData[K]()
+	for u := range g.NodesSeq() {
+		result.AddNode(u)
+		for v, w := range g.successorsSeq(u) {
+			if !other.HasEdge(u, v) {
+				result.AddNode(v)
+				result.setEdge(u, v, w)
+			}
+		}
+	}
+	return result
+}
+
+// helper to build the complement over the same nodes. there's an edge
+// with weight 1.0 between two distinct nodes exactly when there was no
+// edge between them. self-loops are never part of the complement, and
+// self-loops in the original are ignored
+func (g *graphData[K]) complement() graphData[K] {
+	result := newGraphData[K]()
+	for u := range g.NodesSeq() {
+		result.AddNode(u)
+	}
+	for u := range g.NodesSeq() {
+		for v := range g.NodesSeq() {
+			if u != v && !g.HasEdge(u, v) {
+				result.setEdge(u, v, 1.0)
+			}
+		}
+	}
+	return result
+}
+
+// helper to list the differences between two graphs, given the edges
+// of each the way their type reports them. nodes and edges are sorted
+func (g *graphData[K]) diff(other *graphData[K], edges, otherEdges []Edge[K]) ([]Node[K], []Node[K], []Edge[K], []Edge[K]) {
+	// helper to pick out the nodes of one graph missing from another
+	missingNodes := func(from, in *graphData[K]) []Node[K] {
+		nodes := make([]Node[K], 0)
+		for n := range from.NodesSeq() {
+			if !in.HasNode(n) {
+				nodes = append(nodes, n)
+			}
+		}
+		return sortedNodes(nodes)
+	}
+	// helper to pick out the edges of one graph that another doesn't
+	// have with the same weight
+	missingEdges := func(from []Edge[K], in *graphData[K]) []Edge[K] {
+		edges := make([]Edge[K], 0)
+		for _, e := range from {
+			if w, ok := in.Adjacencies[e.u.ID][e.v.ID]; !ok || w != e.weight {
+				edges = append(edges, e)
+			}
+		}
+		return sortedEdges(edges)
+	}
+	return missingNodes(other, g), missingNodes(g, other), missingEdges(otherEdges, g), missingEdges(edges, other)
+}
+
+// function to combine two directed graphs into a new one holding the
+// nodes and edges of both. when both have the same edge its weight is
+// merge(this weight, other weight), or the other weight if merge is nil.
+// the inputs are left untouched
+func (g *DirectedGraph[K]) Union(other *DirectedGraph[K], merge func(a, b float64) float64) *DirectedGraph[K] {
+	return &DirectedGraph[K]{graphData: g.union(&other.graphData, merge)}
+}
+
+// function to create a new directed graph holding the nodes and edges
+// that are in both graphs. edge weights are taken from this graph
+func (g *DirectedGraph[K]) Intersection(other *DirectedGraph[K]) *DirectedGraph[K] {
+	return &DirectedGraph[K]{graphData: g.intersection(&other.graphData)}
+}
+
+// function to create a new directed graph holding all nodes of this
+// graph and those of its edges that aren't in the other graph
+func (g *DirectedGraph[K]) Difference(other *DirectedGraph[K]) *DirectedGraph[K] {
+	return &DirectedGraph[K]{graphData: g.difference(&other.graphData)}
+}
+
+// function to combine two undirected graphs into a new one holding the
+// nodes and edges of both. when both have the same edge its weight is
+// merge(this weight, other weight), or the other weight if merge is nil.
+// the inputs are left untouched
+func (g *UndirectedGraph[K]) Union(other *UndirectedGraph[K], merge func(a, b float64) float64) *UndirectedGraph[K] {
+	return &UndirectedGraph[K]{graphData: g.union(&other.graphData, merge)}
+}
+
+// function to create a new undirected graph holding the nodes and edges
+// that are in both graphs. edge weights are taken from this graph
+func (g *UndirectedGraph[K]) Intersection(other *UndirectedGraph[K]) *UndirectedGraph[K] {
+	return &UndirectedGraph[K]{graphData: g.intersection(&other.graphData)}
+}
+
+// function to create a new undirected graph holding all nodes of this
+// graph and those of its edges that aren't in the other graph
+func (g *UndirectedGraph[K]) Difference(other *UndirectedGraph[K]) *UndirectedGraph[K] {
+	return &UndirectedGraph[K]{graphData: g.difference(&other.graphData)}
+}
+
+// function to create the complement of a directed graph. every ordered
+// pair of distinct nodes without an edge gets one with weight 1.0, and
+// existing edges are dropped. self-loops are neither kept nor added
+func (g *DirectedGraph[K]) Complement() *DirectedGraph[K] {
+	return &DirectedGraph[K]{graphData: g.complement()}
+}
+
+// function to create the complement of an undirected graph. every pair
+// of distinct nodes without an edge gets one with weight 1.0, and
+// existing edges are dropped. self-loops are neither kept nor added
+func (g *UndirectedGraph[K]) Complement() *UndirectedGraph[K] {
+	return &UndirectedGraph[K]{graphData: g.complement()}
+}
+
+// helper to contract the edge between u and v, shared by both graph
+// types. undirected graphs store each edge both ways, so if symmetric is
+// set the rewired edges into u are only stored, not reported to the
+// observers a second time
+func (g *graphData[K]) contractEdge(u, v Node[K], merge func(a, b float64) float64, symmetric bool) Node[K] {
+	if u == v || !g.HasEdge(u, v) {
+		return u
+	}
+	if merge == nil {
+		merge = math.Min
+	}
+
+	// collect the edges of v before it goes away
+	outgoing := maps.Collect(g.successorsSeq(v))
+	incoming := maps.Collect(g.predecessorsSeq(v))
+	g.RemoveNode(v)
+
+	// helper to add a rewired edge, merging weights on collisions
+	rewire := func(from, to Node[K], w float64, notify bool) {
+		if from == u && to == u {
+			return
+		}
+		existing, ok := g.Adjacencies[from.ID][to.ID]
+		if ok {
+			w = merge(existing, w)
+		}
+		g.setEdge(from, to, w)
+		if notify && (!ok || existing != w) {
+			g.notifyAddEdge(from, to, w)
+		}
+	}
+	for x, w := range outgoing {
+		if x != v {
+			rewire(u, x, w, true)
+		}
+	}
+	for x, w := range incoming {
+		if x != v {
+			rewire(x, u, w, !symmetric)
+		}
+	}
+	return u
+}
+
+// function to contract the edge from u to v, merging v into u. every
+// edge of v is rewired to u, and where that collides with an edge u
+// already has, the weight becomes merge(existing, rewired). a nil merge
+// keeps the smaller weight. self-loops the contraction would create,
+// including the u-v edge itself, are dropped. v is removed, and the
+// merged node u is returned. nothing happens if u and v are the same or
+// there is no edge from u to v, since merging nodes that aren't
+// neighbors isn't a contraction
+func (g *DirectedGraph[K]) ContractEdgeFunc(u, v Node[K], merge func(a, b float64) float64) Node[K] {
+	return g.contractEdge(u, v, merge, false)
+}
+
+// function to contract the edge from u to v, keeping the smaller weight
+// when rewired edges collide. see ContractEdgeFunc
+func (g *DirectedGraph[K]) ContractEdge(u, v Node[K]) Node[K] {
+	return g.ContractEdgeFunc(u, v, nil)
+}
+
+// function to contract the edge between u and v, merging v into u. works
+// like it does for directed graphs, see DirectedGraph.ContractEdgeFunc
+func (g *UndirectedGraph[K]) ContractEdgeFunc(u, v Node[K], merge func(a, b float64) float64) Node[K] {
+	return g.contractEdge(u, v, merge, true)
+}
+
+// function to contract the edge between u and v, keeping the smaller
+// weight when rewired edges collide. see ContractEdgeFunc
+func (g *UndirectedGraph[K]) ContractEdge(u, v Node[K]) Node[K] {
+	return g.ContractEdgeFunc(u, v, nil)
+}
+
+// function to rename every node in place by passing its ID through f.
+// edges, weights, and attributes are kept. if f maps two distinct IDs
+// to the same value an error is returned and the graph is left
+// untouched, rather than silently merging the nodes
+func (g *graphData[K]) Relabel(f func(K) K) error {
+	// work out the new IDs first so a collision can't leave the graph
+	// half relabeled
+	mapping := make(map[Node[K]]Node[K], len(g.Adjacencies))
+	seen := make(map[K]K, len(g.Adjacencies))
+	for n := range g.NodesSeq() {
+		id := f(n.ID)
+		if other, ok := seen[id]; ok {
+			return fmt.Errorf("relabel maps both %v and %v to %v", other, n.ID, id)
+		}
+		seen[id] = n.ID
+		mapping[n] = Node[K]{ID: id}
+	}
+
+	relabeled := newGraphData[K]()
+	for u := range g.NodesSeq() {
+		relabeled.AddNode(mapping[u])
+		relabeled.copyAttributes(g, u, mapping[u])
+	}
+	for u := range g.NodesSeq() {
+		for v, w := range g.successorsSeq(u) {
+			relabeled.setEdge(mapping[u], mapping[v], w)
+		}
+	}
+	relabeled.degreeHint, relabeled.noSelfLoops = g.degreeHint, g.noSelfLoops
+	g.replace(relabeled)
+	return nil
+}
+
+// function to create a smaller copy of an undirected graph where every
+// chain of degree-2 nodes, like a corridor in a maze, is replaced by a
+// single edge weighing as much as the whole chain. junctions and dead
+// ends are kept, with their attributes, so shortest paths between them
+// cost the same as before, and searches have far fewer nodes to go
+// through. where chains or edges end up running between the same two
+// nodes, the cheapest one is kept. a chain leading back to the node it
+// started from can't be on a shortest path and is dropped, and a cycle
+// made only of degree-2 nodes is left as a single node. a node with a
+// self-loop counts as a junction
+func (g *UndirectedGraph[K]) CompressChains() *UndirectedGraph[K] {
+	h := NewUndirectedGraph[K]()
+	h.noSelfLoops = g.noSelfLoops
+
+	// helper to tell whether a node is in the middle of a chain
+	inChain := func(n Node[K]) bool {
+		return !g.HasSelfLoop(n) && len(g.loopFreeNeighbors(n)) == 2
+	}
+	// helper to add an edge, or make an existing one cheaper
+	link := func(u, v Node[K], w float64) {
+		if existing, ok := h.Adjacencies[u.ID][v.ID]; !ok || w < existing {
+			h.AddEdge(u, v, w)
+		}
+	}
+
+	visited := make(map[Node[K]]bool)
+	for n := range g.NodesSeq() {
+		if !inChain(n) {
+			h.AddNode(n)
+			h.copyAttributes(&g.graphData, n, n)
+		}
+	}
+	for u := range h.NodesSeq() {
+		for v, w := range g.successorsSeq(u) {
+			if v == u {
+				h.AddEdge(u, u, w)
+				continue
+			}
+			// follow the chain to the node at its other end
+			prev, current, total := u, v, w
+			for inChain(current) {
+				visited[current] = true
+				neighbors := g.loopFreeNeighbors(current)
+				next := neighbors[0]
+				if next == prev {
+					next = neighbors[1]
+				}
+				total += g.Adjacencies[current.ID][next.ID]
+				prev, current = current, next
+			}
+			if current != u {
+				link(u, current, total)
+			}
+		}
+	}
+
+	// chain nodes that weren't reached from a junction go around a cycle
+	// on their own. keep one node of each
+	for _, n := range sortedNodes(g.Nodes()) {
+		if !inChain(n) || visited[n] {
+			continue
+		}
+		h.AddNode(n)
+		h.copyAttributes(&g.graphData, n, n)
+		for current := range g.BFSIter(n) {
+			visited[current] = true
+		}
+	}
+	return h
+}
+
+// function to build the line graph of a directed graph. every edge of
+// the graph becomes a node, keyed by the Edge itself, weight included,
+// and there's an edge from one to another wherever the first ends where
+// the second starts, so walks through the line graph follow walks
+// through the graph. edges of the line graph weigh 1.0. this can't be a
+// method, since graphs of edges would have a method making graphs of
+// edges of edges, and so on, which Go doesn't allow
+func DirectedLineGraph[K comparable](g *DirectedGraph[K]) *DirectedGraph[Edge[K]] {
+	h := NewDirectedGraph[Edge[K]]()
+	for e := range g.EdgesSeq() {
+		h.AddNode(Node[Edge[K]]{ID: e})
+	}
+	for e := range g.EdgesSeq() {
+		for next, w := range g.successorsSeq(e.v) {
+			h.AddEdge(Node[Edge[K]]{ID: e}, Node[Edge[K]]{ID: NewEdge(e.v, next, w)}, 1.0)
+		}
+	}
+	return h
+}
+
+// function to build the line graph of an undirected graph. every edge of
+// the graph becomes a node, keyed by the Edge itself, oriented the way
+// Edges reports it and with its weight, and two of them are connected
+// when they share an end point. edges of the line graph weigh 1.0. a
+// self-loop shares its node with the other edges there, but isn't
+// connected to itself. like DirectedLineGraph, this isn't a method
+func LineGraph[K comparable](g *UndirectedGraph[K]) *UndirectedGraph[Edge[K]] {
+	h := NewUndirectedGraph[Edge[K]]()
+	for _, e := range g.Edges() {
+		h.AddNode(Node[Edge[K]]{ID: e})
+	}
+	// all the edges meeting at a node are connected to each other
+	for n := range g.NodesSeq() {
+		incident := make([]Node[Edge[K]], 0, g.OutDegree(n))
+		for v := range g.successorsSeq(n) {
+			incident = append(incident, Node[Edge[K]]{ID: g.orientedEdge(n, v)})
+		}
+		for i := range incident {
+			for j := i + 1; j < len(incident); j++ {
+				h.AddEdge(incident[i], incident[j], 1.0)
+			}
+		}
+	}
+	return h
+}
+
+// function to list what changed between this directed graph and other,
+// as the nodes and edges other added and removed. an edge whose weight
+// changed shows up as removed with its old weight and added with its
+// new one. everything is sorted, see SortedNodes and SortedEdges
+func (g *DirectedGraph[K]) Diff(other *DirectedGraph[K]) (addedNodes, removedNodes []Node[K], addedEdges, removedEdges []Edge[K]) {
+	return g.diff(&other.graphData, g.Edges(), other.Edges())
+}
+
+// like the Diff of directed graphs, with each edge reported once, the
+// way Edges reports it
+func (g *UndirectedGraph[K]) Diff(other *UndirectedGraph[K]) (addedNodes, removedNodes []Node[K], addedEdges, removedEdges []Edge[K]) {
+	return g.diff(&other.graphData, g.Edges(), other.Edges())
+}
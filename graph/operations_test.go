@@ -0,0 +1,433 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphSetOperations(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	// two graphs sharing the edge between u and v
+	g := NewUndirectedGraph[int]()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 2.0)
+	h := NewUndirectedGraph[int]()
+	h.AddEdge(u, v, 4.0)
+	h.AddEdge(w, x, 3.0)
+
+	t.Run("Union with overwrite", func(t *testing.T) {
+		union := g.Union(h, nil)
+		if n := union.NumberOfNodes(); n != 4 {
+			t.Errorf("Expected 4 nodes, got %d", n)
+		}
+		if !union.HasEdge(v, w) || !union.HasEdge(w, x) {
+			t.Errorf("Expected edges from both graphs")
+		}
+		if union.Adjacencies[u.ID][v.ID] != 4.0 || union.Adjacencies[v.ID][u.ID] != 4.0 {
+			t.Errorf("Expected shared edge to take the other weight, got %f", union.Adjacencies[u.ID][v.ID])
+		}
+	})
+
+	t.Run("Union with summed weights", func(t *testing.T) {
+		sum := func(a, b float64) float64 { return a + b }
+		union := g.Union(h, sum)
+		if union.Adjacencies[u.ID][v.ID] != 5.0 || union.Adjacencies[v.ID][u.ID] != 5.0 {
+			t.Errorf("Expected shared edge to have summed weight 5.0, got %f", union.Adjacencies[u.ID][v.ID])
+		}
+		if union.Adjacencies[w.ID][x.ID] != 3.0 {
+			t.Errorf("Expected unshared edge to keep its weight, got %f", union.Adjacencies[w.ID][x.ID])
+		}
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		inter := g.Intersection(h)
+		if n := inter.NumberOfNodes(); n != 3 {
+			t.Errorf("Expected 3 nodes, got %d", n)
+		}
+		if !inter.HasEdge(u, v) || inter.HasEdge(v, w) || inter.HasEdge(w, x) {
+			t.Errorf("Expected only the shared edge")
+		}
+		if inter.Adjacencies[u.ID][v.ID] != 1.0 {
+			t.Errorf("Expected weight from the first graph, got %f", inter.Adjacencies[u.ID][v.ID])
+		}
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		diff := g.Difference(h)
+		if n := diff.NumberOfNodes(); n != 3 {
+			t.Errorf("Expected 3 nodes, got %d", n)
+		}
+		if diff.HasEdge(u, v) || !diff.HasEdge(v, w) {
+			t.Errorf("Expected only the edge between v and w")
+		}
+	})
+
+	t.Run("Inputs are untouched", func(t *testing.T) {
+		g.Union(h, nil).AddEdge(u, x, 1.0)
+		if g.Adjacencies[u.ID][v.ID] != 1.0 || h.Adjacencies[u.ID][v.ID] != 4.0 || g.HasEdge(u, x) {
+			t.Errorf("Expected inputs to be unchanged")
+		}
+	})
+
+	t.Run("Directed difference", func(t *testing.T) {
+		a := NewDirectedGraph[int]()
+		a.AddEdge(u, v, 1.0)
+		a.AddEdge(v, u, 1.0)
+		b := NewDirectedGraph[int]()
+		b.AddEdge(u, v, 1.0)
+		diff := a.Difference(b)
+		if diff.HasEdge(u, v) || !diff.HasEdge(v, u) {
+			t.Errorf("Expected only the edge from v to u")
+		}
+	})
+}
+
+func TestComplement(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Undirected complement", func(t *testing.T) {
+		// a path over four nodes, with a self loop
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 3.0)
+		g.AddEdge(v, w, 3.0)
+		g.AddEdge(w, x, 3.0)
+		g.AddEdge(u, u, 3.0)
+
+		c := g.Complement()
+		if n := c.NumberOfNodes(); n != 4 {
+			t.Errorf("Expected 4 nodes, got %d", n)
+		}
+		// 6 possible pairs, 3 of which were in the original
+		for _, pair := range [][2]Node[int]{{u, w}, {u, x}, {v, x}} {
+			if !c.HasEdge(pair[0], pair[1]) || c.Adjacencies[pair[1].ID][pair[0].ID] != 1.0 {
+				t.Errorf("Expected edge between %v and %v with weight 1.0", pair[0], pair[1])
+			}
+		}
+		if c.HasEdge(u, v) || c.HasEdge(u, u) || c.HasEdge(v, v) {
+			t.Errorf("Expected original edges and self loops to be absent")
+		}
+	})
+
+	t.Run("Directed complement", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddNode(w)
+
+		c := g.Complement()
+		// 6 ordered pairs, one of which was in the original
+		if n := c.NumberOfEdges(); n != 5 {
+			t.Errorf("Expected 5 edges, got %d", n)
+		}
+		if c.HasEdge(u, v) || !c.HasEdge(v, u) {
+			t.Errorf("Expected only the reverse of the original edge")
+		}
+	})
+}
+
+func TestContractEdge(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Undirected square", func(t *testing.T) {
+		// u-v-w-x-u, plus a chord from v to x
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, x, 3.0)
+		g.AddEdge(x, u, 4.0)
+		g.AddEdge(v, x, 5.0)
+
+		merged := g.ContractEdge(u, v)
+		if merged != u {
+			t.Errorf("Expected merged node %v, got %v", u, merged)
+		}
+		if g.HasNode(v) {
+			t.Errorf("Expected %v to be removed", v)
+		}
+		if n := g.NumberOfNodes(); n != 3 {
+			t.Errorf("Expected 3 nodes, got %d", n)
+		}
+		// u-w, w-x, and u-x with the parallel edges collapsed
+		if n := g.NumberOfEdges(); n != 3 {
+			t.Errorf("Expected 3 edges, got %d", n)
+		}
+		if g.HasEdge(u, u) {
+			t.Errorf("Expected no self-loop after contraction")
+		}
+		if weight := g.Adjacencies[x.ID][u.ID]; weight != 4.0 {
+			t.Errorf("Expected minimum weight 4.0, got %f", weight)
+		}
+	})
+
+	t.Run("Summing policy", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 2.0)
+		g.AddEdge(v, w, 3.0)
+
+		g.ContractEdgeFunc(u, v, func(a, b float64) float64 { return a + b })
+		if weight := g.Adjacencies[u.ID][w.ID]; weight != 5.0 {
+			t.Errorf("Expected summed weight 5.0, got %f", weight)
+		}
+		if weight := g.Adjacencies[w.ID][u.ID]; weight != 5.0 {
+			t.Errorf("Expected summed weight 5.0, got %f", weight)
+		}
+	})
+
+	t.Run("Directed edges keep their direction", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(x, v, 1.0)
+		g.AddEdge(v, u, 1.0)
+
+		g.ContractEdge(u, v)
+		if n := g.NumberOfNodes(); n != 3 {
+			t.Errorf("Expected 3 nodes, got %d", n)
+		}
+		if n := g.NumberOfEdges(); n != 2 {
+			t.Errorf("Expected 2 edges, got %d", n)
+		}
+		if !g.HasEdge(u, w) || !g.HasEdge(x, u) {
+			t.Errorf("Expected edges u->w and x->u, got %v", g.Edges())
+		}
+	})
+
+	t.Run("No edge between the nodes", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+
+		if merged := g.ContractEdge(u, w); merged != u {
+			t.Errorf("Expected %v back, got %v", u, merged)
+		}
+		if g.NumberOfNodes() != 3 || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected the graph to be unchanged, got %v", g.Edges())
+		}
+
+		// a directed edge can only be contracted along its direction
+		d := NewDirectedGraph[int]()
+		d.AddEdge(v, u, 1.0)
+		d.ContractEdge(u, v)
+		if !d.HasNode(v) || !d.HasEdge(v, u) {
+			t.Errorf("Expected the graph to be unchanged, got %v", d.Edges())
+		}
+	})
+}
+
+func TestRelabel(t *testing.T) {
+	t.Run("Compress sparse IDs", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{10}, Node[int]{20}, 1.5)
+		g.AddEdge(Node[int]{20}, Node[int]{30}, 2.5)
+		g.AddNode(Node[int]{40})
+
+		if err := g.Relabel(func(id int) int { return id/10 - 1 }); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if g.NumberOfNodes() != 4 || g.NumberOfEdges() != 2 {
+			t.Errorf("Expected 4 nodes and 2 edges, got %d and %d", g.NumberOfNodes(), g.NumberOfEdges())
+		}
+		if w := g.Adjacencies[0][1]; w != 1.5 {
+			t.Errorf("Expected edge 0->1 with weight 1.5, got %f", w)
+		}
+		if w := g.Adjacencies[1][2]; w != 2.5 {
+			t.Errorf("Expected edge 1->2 with weight 2.5, got %f", w)
+		}
+		if !g.HasNode(Node[int]{3}) || g.HasNode(Node[int]{10}) {
+			t.Errorf("Expected only relabeled nodes, got %v", g.Nodes())
+		}
+	})
+
+	t.Run("Collisions are an error", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+
+		if err := g.Relabel(func(id int) int { return id % 2 }); err == nil {
+			t.Errorf("Expected an error for colliding IDs")
+		}
+		// the graph is untouched
+		if !g.HasEdge(Node[int]{1}, Node[int]{2}) || g.NumberOfNodes() != 3 {
+			t.Errorf("Expected graph to be unchanged, got %v", g.Edges())
+		}
+	})
+}
+
+func TestCompressChains(t *testing.T) {
+	t.Run("Path", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4})
+		g.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 2.5)
+		g.SetNodeAttribute(Node[int]{1}, "name", "start")
+		h := g.CompressChains()
+		if h.NumberOfNodes() != 2 || h.NumberOfEdges() != 1 {
+			t.Errorf("Expected a single edge, got %v", h.Edges())
+		}
+		if w, ok := h.EdgeWeight(Node[int]{4}, Node[int]{1}); !ok || w != 4.5 {
+			t.Errorf("Expected weight 4.5 between the ends, got %f", w)
+		}
+		if name, ok := h.NodeAttribute(Node[int]{1}, "name"); !ok || name != "start" {
+			t.Errorf("Expected attributes to be kept, got %v", name)
+		}
+		if g.NumberOfNodes() != 4 {
+			t.Errorf("Expected original graph to be unchanged")
+		}
+	})
+
+	t.Run("Parallel chains", func(t *testing.T) {
+		// two routes from 1 to 4, via 2 or via 3 and 5
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{4}, 5.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 1.0)
+		g.AddEdge(Node[int]{3}, Node[int]{5}, 1.0)
+		g.AddEdge(Node[int]{5}, Node[int]{4}, 1.0)
+		g.AddEdge(Node[int]{4}, Node[int]{6}, 1.0)
+		g.AddEdge(Node[int]{1}, Node[int]{7}, 1.0)
+		h := g.CompressChains()
+		if w, ok := h.EdgeWeight(Node[int]{1}, Node[int]{4}); !ok || w != 3.0 {
+			t.Errorf("Expected the cheaper chain with weight 3, got %f", w)
+		}
+		if h.NumberOfNodes() != 4 || h.NumberOfEdges() != 3 {
+			t.Errorf("Expected 4 nodes and 3 edges, got %v", h.Edges())
+		}
+	})
+
+	t.Run("Cycle of chain nodes", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{4}, Node[int]{5}, 1.0)
+		h := g.CompressChains()
+		if !slices.Equal(h.SortedNodes(nil), []Node[int]{{1}, {4}, {5}}) || h.NumberOfEdges() != 1 {
+			t.Errorf("Expected the cycle to shrink to node 1, got %v and %v", h.SortedNodes(nil), h.Edges())
+		}
+	})
+
+	t.Run("Maze costs are preserved", func(t *testing.T) {
+		grid := []string{
+			"#########",
+			"#.......#",
+			"#.#.###.#",
+			"#.#...#.#",
+			"#.###.#.#",
+			"#...#...#",
+			"###.###.#",
+			"#.......#",
+			"#########",
+		}
+		g, err := BuildGridGraph(grid, CardinalDirections, '.')
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		h := g.CompressChains()
+		if h.NumberOfNodes() >= g.NumberOfNodes() {
+			t.Errorf("Expected fewer nodes, got %d of %d", h.NumberOfNodes(), g.NumberOfNodes())
+		}
+		for u := range h.NodesSeq() {
+			before, _ := g.Dijkstra(u)
+			after, _ := h.Dijkstra(u)
+			for v := range h.NodesSeq() {
+				if before[v] != after[v] {
+					t.Errorf("Expected distance %f from %v to %v, got %f", before[v], u, v, after[v])
+				}
+			}
+		}
+	})
+}
+
+func TestLineGraph(t *testing.T) {
+	// helper to turn a pair of IDs into a node of a line graph
+	edgeNode := func(u, v int, w float64) Node[Edge[int]] {
+		return Node[Edge[int]]{NewEdge(Node[int]{u}, Node[int]{v}, w)}
+	}
+
+	t.Run("Triangle", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		g.SetEdgeWeight(Node[int]{3}, Node[int]{1}, 2.0)
+		h := LineGraph(g)
+		if h.NumberOfNodes() != 3 || h.NumberOfEdges() != 3 {
+			t.Errorf("Expected a triangle, got %v", h.Edges())
+		}
+		if !h.HasEdge(edgeNode(1, 2, 1.0), edgeNode(1, 3, 2.0)) || !h.HasEdge(edgeNode(2, 3, 1.0), edgeNode(1, 2, 1.0)) {
+			t.Errorf("Expected edges keyed in canonical orientation, got %v", h.Nodes())
+		}
+		if !h.IsIsomorphic(CycleGraph([]Edge[int]{{}, {weight: 1}, {weight: 2}})) {
+			t.Errorf("Expected the line graph of a triangle to be a triangle")
+		}
+	})
+
+	t.Run("Star", func(t *testing.T) {
+		// the edges of a star all meet in the middle, so they form a clique
+		h := LineGraph(StarGraph([]int{1, 2, 3, 4}))
+		if h.NumberOfNodes() != 3 || h.NumberOfEdges() != 3 {
+			t.Errorf("Expected a triangle, got %v", h.Edges())
+		}
+	})
+
+	t.Run("Self-loop", func(t *testing.T) {
+		g := PathGraph([]int{1, 2})
+		g.AddEdge(Node[int]{2}, Node[int]{2}, 1.0)
+		h := LineGraph(g)
+		if h.NumberOfEdges() != 1 || !h.HasEdge(edgeNode(1, 2, 1.0), edgeNode(2, 2, 1.0)) {
+			t.Errorf("Expected the loop to be connected to the other edge only, got %v", h.Edges())
+		}
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		g.AddEdge(Node[int]{3}, Node[int]{1}, 1.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 1.0)
+		h := DirectedLineGraph(g)
+		if h.NumberOfNodes() != 4 || h.NumberOfEdges() != 5 {
+			t.Errorf("Expected 4 nodes and 5 edges, got %v", h.Edges())
+		}
+		if !h.HasEdge(edgeNode(3, 1, 1.0), edgeNode(1, 3, 1.0)) || h.HasEdge(edgeNode(1, 3, 1.0), edgeNode(1, 2, 1.0)) {
+			t.Errorf("Expected edges to follow the direction of the graph, got %v", h.Edges())
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("Undirected", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{3}, Node[int]{4}, 1.0)
+		h := PathGraph([]int{1, 2, 3})
+		h.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 2.0)
+		h.AddEdge(Node[int]{5}, Node[int]{1}, 3.0)
+
+		addedNodes, removedNodes, addedEdges, removedEdges := g.Diff(h)
+		if !slices.Equal(addedNodes, []Node[int]{{5}}) || !slices.Equal(removedNodes, []Node[int]{{4}}) {
+			t.Errorf("Expected node 5 added and 4 removed, got %v and %v", addedNodes, removedNodes)
+		}
+		// the reweighted edge is removed and added again
+		expectedAdded := []Edge[int]{NewEdge(Node[int]{1}, Node[int]{5}, 3.0), NewEdge(Node[int]{2}, Node[int]{3}, 2.0)}
+		expectedRemoved := []Edge[int]{NewEdge(Node[int]{2}, Node[int]{3}, 1.0), NewEdge(Node[int]{3}, Node[int]{4}, 1.0)}
+		if !slices.Equal(addedEdges, expectedAdded) {
+			t.Errorf("Expected added edges %v, got %v", expectedAdded, addedEdges)
+		}
+		if !slices.Equal(removedEdges, expectedRemoved) {
+			t.Errorf("Expected removed edges %v, got %v", expectedRemoved, removedEdges)
+		}
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		h := NewDirectedGraph[int]()
+		h.AddEdge(Node[int]{2}, Node[int]{1}, 1.0)
+		_, _, addedEdges, removedEdges := g.Diff(h)
+		if !slices.Equal(addedEdges, []Edge[int]{NewEdge(Node[int]{2}, Node[int]{1}, 1.0)}) ||
+			!slices.Equal(removedEdges, []Edge[int]{NewEdge(Node[int]{1}, Node[int]{2}, 1.0)}) {
+			t.Errorf("Expected the reversed edge to be added and removed, got %v and %v", addedEdges, removedEdges)
+		}
+	})
+
+	t.Run("Same graph", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		addedNodes, removedNodes, addedEdges, removedEdges := g.Diff(g.Subgraph(g.Nodes()))
+		if len(addedNodes)+len(removedNodes)+len(addedEdges)+len(removedEdges) != 0 {
+			t.Errorf("Expected no differences, got %v %v %v %v", addedNodes, removedNodes, addedEdges, removedEdges)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// paths print as their node IDs joined by arrows, like A -> B -> C
+func (p Path[K]) String() string {
+	ids := make([]string, len(p))
+	for i, n := range p {
+		ids[i] = fmt.Sprint(n.ID)
+	}
+	return strings.Join(ids, " -> ")
+}
+
+// helper to sum up the edge weights along a path in a graph. returns
+// the total and -1, or the index of the first step without an edge
+func (p Path[K]) weight(g Graph[K]) (float64, int) {
+	total := 0.0
+	for i := 1; i < len(p); i++ {
+		w, ok := g.EdgeWeight(p[i-1], p[i])
+		if !ok {
+			return 0.0, i
+		}
+		total += w
+	}
+	return total, -1
+}
+
+// function to check whether every pair of consecutive nodes on the path
+// is connected by an edge in the graph. paths of fewer than two nodes
+// have no steps to check, so they're always valid
+func (p Path[K]) IsValidPath(g Graph[K]) bool {
+	_, broken := p.weight(g)
+	return broken < 0
+}
+
+// function to sum up the edge weights along a path in a graph. returns
+// the total and true, or 0 and false if the path isn't valid
+func (p Path[K]) PathWeight(g Graph[K]) (float64, bool) {
+	total, broken := p.weight(g)
+	return total, broken < 0
+}
+
+// like PathWeight, but the error names the first missing edge. paths
+// of fewer than two nodes cost nothing
+func (p Path[K]) Cost(g Graph[K]) (float64, error) {
+	total, broken := p.weight(g)
+	if broken >= 0 {
+		return 0.0, fmt.Errorf("no edge from %v to %v", p[broken-1].ID, p[broken].ID)
+	}
+	return total, nil
+}
+
+// helper to apply f to the weight of every step along a path. the whole
+// path is checked first, so a broken path leaves the graph untouched.
+// a step the path takes more than once gets f applied more than once
+func updatePathWeights[K comparable](g Graph[K], p Path[K], f func(old float64) float64) error {
+	if _, err := p.Cost(g); err != nil {
+		return err
+	}
+	for i := 1; i < len(p); i++ {
+		w, _ := g.EdgeWeight(p[i-1], p[i])
+		g.SetEdgeWeight(p[i-1], p[i], f(w))
+	}
+	return nil
+}
+
+// function to apply f to the weight of every edge along a path, for
+// example to make a route more expensive after it has been used.
+// errors without changing anything if any step isn't an edge
+func (g *DirectedGraph[K]) UpdatePathWeights(p Path[K], f func(old float64) float64) error {
+	return updatePathWeights(g, p, f)
+}
+
+// like the directed version, but updates the edges both ways
+func (g *UndirectedGraph[K]) UpdatePathWeights(p Path[K], f func(old float64) float64) error {
+	return updatePathWeights(g, p, f)
+}
@@ -0,0 +1,156 @@
+package graph
+
+import "testing"
+
+func TestPathString(t *testing.T) {
+	cases := map[string]struct {
+		path     Path[Coordinate]
+		expected string
+	}{
+		"Empty path":  {Path[Coordinate]{}, ""},
+		"Single node": {Path[Coordinate]{{Coordinate{1, 2}}}, "(1, 2)"},
+		"Several nodes": {
+			Path[Coordinate]{{Coordinate{0, 0}}, {Coordinate{0, 1}}, {Coordinate{1, 1}}},
+			"(0, 0) -> (0, 1) -> (1, 1)",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if s := c.path.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q", c.expected, s)
+			}
+		})
+	}
+}
+
+func TestPathCost(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Directed path", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.AddEdge(v, w, 2.5)
+
+		cost, err := Path[int]{u, v, w}.Cost(g)
+		if err != nil || cost != 4.0 {
+			t.Errorf("Expected cost 4.0, got %f and %v", cost, err)
+		}
+		// against the direction of the edges
+		if _, err := (Path[int]{w, v, u}).Cost(g); err == nil {
+			t.Errorf("Expected error for a path against the edges")
+		}
+	})
+
+	t.Run("Undirected path", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.5)
+		g.AddEdge(v, w, 2.5)
+
+		cost, err := Path[int]{w, v, u}.Cost(g)
+		if err != nil || cost != 4.0 {
+			t.Errorf("Expected cost 4.0, got %f and %v", cost, err)
+		}
+		if _, err := (Path[int]{u, v, x}).Cost(g); err == nil {
+			t.Errorf("Expected error for a missing edge")
+		}
+	})
+
+	t.Run("Trivial paths", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		for _, p := range []Path[int]{{}, {u}} {
+			if cost, err := p.Cost(g); err != nil || cost != 0.0 {
+				t.Errorf("Expected cost 0.0 for %v, got %f and %v", p, cost, err)
+			}
+		}
+	})
+
+	t.Run("Matches Dijkstra", func(t *testing.T) {
+		g := GridGraph(5, 5)
+		path, _, expected := g.DijkstraTo(Node[Coordinate]{Coordinate{0, 0}}, Node[Coordinate]{Coordinate{4, 3}})
+		if cost, err := path.Cost(g); err != nil || cost != expected {
+			t.Errorf("Expected cost %f, got %f and %v", expected, cost, err)
+		}
+	})
+}
+
+func TestIsValidPath(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+	g := NewDirectedGraph[int]()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 2.0)
+	g.AddEdge(w, x, 3.0)
+
+	cases := map[string]struct {
+		path   Path[int]
+		valid  bool
+		weight float64
+	}{
+		"Full path":         {Path[int]{u, v, w, x}, true, 6.0},
+		"Partial path":      {Path[int]{v, w}, true, 2.0},
+		"Single node":       {Path[int]{x}, true, 0.0},
+		"Empty path":        {Path[int]{}, true, 0.0},
+		"Skipped node":      {Path[int]{u, w, x}, false, 0.0},
+		"Against edge":      {Path[int]{x, w}, false, 0.0},
+		"Node not in graph": {Path[int]{u, v, Node[int]{42}}, false, 0.0},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if valid := c.path.IsValidPath(g); valid != c.valid {
+				t.Errorf("Expected valid to be %t, got %t", c.valid, valid)
+			}
+			weight, ok := c.path.PathWeight(g)
+			if ok != c.valid || weight != c.weight {
+				t.Errorf("Expected %f and %t, got %f and %t", c.weight, c.valid, weight, ok)
+			}
+		})
+	}
+}
+
+func TestUpdatePathWeights(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+	double := func(old float64) float64 { return 2 * old }
+
+	t.Run("Directed path", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+		g.AddEdge(w, x, 3.0)
+
+		if err := g.UpdatePathWeights(Path[int]{u, v, w}, double); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cost, _ := (Path[int]{u, v, w, x}).Cost(g); cost != 9.0 {
+			t.Errorf("Expected cost 9.0 after update, got %f", cost)
+		}
+	})
+
+	t.Run("Undirected path", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+
+		if err := g.UpdatePathWeights(Path[int]{w, v, u}, double); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// both directions see the new weights
+		for _, p := range []Path[int]{{u, v, w}, {w, v, u}} {
+			if cost, _ := p.Cost(g); cost != 6.0 {
+				t.Errorf("Expected cost 6.0 for %v, got %f", p, cost)
+			}
+		}
+	})
+
+	t.Run("Broken path", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 2.0)
+
+		if err := g.UpdatePathWeights(Path[int]{u, v, w, x}, double); err == nil {
+			t.Errorf("Expected error for a missing edge")
+		}
+		// nothing changed
+		if cost, _ := (Path[int]{u, v, w}).Cost(g); cost != 3.0 {
+			t.Errorf("Expected cost 3.0 after failed update, got %f", cost)
+		}
+	})
+}
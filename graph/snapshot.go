@@ -0,0 +1,143 @@
+package graph
+
+import "errors"
+
+// a point in the history of a graph that it can be rolled back to, see
+// Snapshot. states are only meaningful to the graph they came from
+type GraphState struct {
+	log    *changeLog
+	serial int
+}
+
+// an entry in the change log. snapshots leave a marker holding their
+// serial number, every other change leaves a function that undoes it
+type logEntry struct {
+	serial int
+	undo   func()
+}
+
+// the changes made to a graph since its first snapshot was taken, and
+// how many snapshots were handed out, to number them
+type changeLog struct {
+	entries []logEntry
+	serials int
+}
+
+// helper to add a function undoing a change to the log, if the graph
+// has snapshots
+func (g *graphData[K]) record(undo func()) {
+	if g.log != nil {
+		g.log.entries = append(g.log.entries, logEntry{undo: undo})
+	}
+}
+
+// helper to log the edge from u to v as it is now, before it changes,
+// so that undoing puts back its weight or removes it if it was missing
+func (g *graphData[K]) logEdge(u, v K) {
+	if g.log == nil {
+		return
+	}
+	if w, ok := g.Adjacencies[u][v]; ok {
+		g.record(func() {
+			g.Adjacencies[u][v] = w
+			g.predecessors[v][u] = w
+		})
+	} else {
+		g.record(func() {
+			delete(g.Adjacencies[u], v)
+			delete(g.predecessors[v], u)
+		})
+	}
+}
+
+// helper to log an attribute of a node as it is now, before it changes
+func (g *graphData[K]) logAttribute(id K, key string) {
+	if g.log == nil {
+		return
+	}
+	if value, ok := g.attributes[id][key]; ok {
+		g.record(func() {
+			if g.attributes[id] == nil {
+				g.attributes[id] = make(map[string]any)
+			}
+			g.attributes[id][key] = value
+		})
+	} else {
+		g.record(func() {
+			delete(g.attributes[id], key)
+			if len(g.attributes[id]) == 0 {
+				delete(g.attributes, id)
+			}
+		})
+	}
+}
+
+// helper to replace the contents of the graph with another one, keeping
+// the change log so that the replacement can be undone, and the
+// observers
+func (g *graphData[K]) replace(other graphData[K]) {
+	old := *g
+	other.log, other.observers = g.log, g.observers
+	*g = other
+	g.record(func() {
+		log, observers := g.log, g.observers
+		*g = old
+		g.log, g.observers = log, observers
+	})
+}
+
+// function to take a snapshot of the graph, which Restore can roll it
+// back to later, for backtracking searches that change a graph and then
+// undo it. taking a snapshot is O(1). from then on the graph logs every
+// change made through its methods, which costs a little on each change,
+// and restoring takes time in proportion to the number of changes since
+// the snapshot, not the size of the graph. nodes, edges, attributes, and
+// settings like DisallowSelfLoops are all covered, but changes written
+// to Adjacencies directly are not.
+//
+// snapshots don't hold a copy of the graph, so they don't alias any of
+// it. they're only positions in the log, and the log keeps growing
+// while any are held, so call DropSnapshots once they're no longer
+// needed. snapshots can be nested, and restored any number of times.
+// copies of the graph start out without snapshots
+func (g *graphData[K]) Snapshot() GraphState {
+	if g.log == nil {
+		g.log = &changeLog{}
+	}
+	g.log.serials++
+	g.log.entries = append(g.log.entries, logEntry{serial: g.log.serials})
+	return GraphState{log: g.log, serial: g.log.serials}
+}
+
+// function to roll the graph back to the way it was when a snapshot was
+// taken. the snapshot stays valid, so the graph can be rolled back to it
+// again, but snapshots taken after it are gone. errors if the snapshot
+// is from another graph, from before DropSnapshots, or is gone
+func (g *graphData[K]) Restore(s GraphState) error {
+	if g.log == nil || s.log != g.log {
+		return errors.New("snapshot doesn't belong to this graph, or was dropped")
+	}
+	entries := g.log.entries
+	i := len(entries) - 1
+	for i >= 0 && entries[i].serial != s.serial {
+		i--
+	}
+	if i < 0 {
+		return errors.New("snapshot is gone, the graph was restored to an earlier one")
+	}
+	// undo the changes newest first, each one sees the graph the way it
+	// was right after the change
+	for j := len(entries) - 1; j > i; j-- {
+		if entries[j].undo != nil {
+			entries[j].undo()
+		}
+	}
+	g.log.entries = entries[:i+1]
+	return nil
+}
+
+// function to drop all snapshots of the graph and stop logging changes.
+// the graph stays as it is, and the dropped snapshots can't be restored
+func (g *graphData[K]) DropSnapshots() {
+	g.log = nil
+}
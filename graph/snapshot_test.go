@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"maps"
+	"testing"
+)
+
+// helper to check that two graphs hold the same nodes, edges, and
+// attributes, with matching predecessor tables
+func sameGraph[K comparable](t *testing.T, expected, got *graphData[K]) {
+	t.Helper()
+	if !sameAdjacencies(expected.Adjacencies, got.Adjacencies) {
+		t.Errorf("Expected adjacencies %v, got %v", expected.Adjacencies, got.Adjacencies)
+	}
+	if !sameAdjacencies(expected.predecessors, got.predecessors) {
+		t.Errorf("Expected predecessors %v, got %v", expected.predecessors, got.predecessors)
+	}
+	if !maps.EqualFunc(expected.attributes, got.attributes, func(a, b map[string]any) bool { return maps.Equal(a, b) }) {
+		t.Errorf("Expected attributes %v, got %v", expected.attributes, got.attributes)
+	}
+	if expected.noSelfLoops != got.noSelfLoops || expected.degreeHint != got.degreeHint {
+		t.Errorf("Expected settings to match")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	// helper to build the graph the tests start from
+	build := func() *UndirectedGraph[int] {
+		g := PathGraph([]int{1, 2, 3, 4})
+		g.AddEdge(Node[int]{4}, Node[int]{4}, 2.0)
+		g.SetNodeAttribute(Node[int]{2}, "name", "two")
+		return g
+	}
+
+	t.Run("Restore undoes every kind of change", func(t *testing.T) {
+		g := build()
+		s := g.Snapshot()
+		g.AddEdge(Node[int]{1}, Node[int]{5}, 3.0)
+		g.SetEdgeWeight(Node[int]{2}, Node[int]{3}, 7.0)
+		g.RemoveNode(Node[int]{2})
+		g.SetNodeAttribute(Node[int]{1}, "name", "one")
+		g.RemoveNodeAttribute(Node[int]{1}, "name")
+		g.SetNodeAttribute(Node[int]{3}, "seen", true)
+		g.ContractEdge(Node[int]{3}, Node[int]{4})
+		g.MapWeights(func(w float64) float64 { return -w })
+		g.DisallowSelfLoops()
+		g.Reserve(10, 40)
+		if err := g.Restore(s); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		sameGraph(t, &build().graphData, &g.graphData)
+	})
+
+	t.Run("Only changes are logged", func(t *testing.T) {
+		g := build()
+		g.Snapshot()
+		g.RemoveSelfLoops()
+		// the snapshot marker, and the one self-loop that was removed
+		if n := len(g.log.entries); n != 2 {
+			t.Errorf("Expected 2 log entries, got %d", n)
+		}
+	})
+
+	t.Run("Clear and relabel", func(t *testing.T) {
+		g := build()
+		s := g.Snapshot()
+		g.Clear()
+		if g.NumberOfNodes() != 0 {
+			t.Errorf("Expected graph to be cleared")
+		}
+		g.Restore(s)
+		if err := g.Relabel(func(id int) int { return id * 10 }); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		g.AddEdge(Node[int]{10}, Node[int]{30}, 1.0)
+		g.Restore(s)
+		sameGraph(t, &build().graphData, &g.graphData)
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		s := g.Snapshot()
+		g.AddEdge(Node[int]{2}, Node[int]{1}, 4.0)
+		g.RemoveEdge(Node[int]{1}, Node[int]{2})
+		g.Restore(s)
+		if !g.HasEdge(Node[int]{1}, Node[int]{2}) || g.HasEdge(Node[int]{2}, Node[int]{1}) || len(g.predecessors[1]) != 0 {
+			t.Errorf("Expected only the edge from 1 to 2, got %v", g.Adjacencies)
+		}
+	})
+
+	t.Run("Backtracking", func(t *testing.T) {
+		// restore the same snapshot over and over, with nested ones
+		g := build()
+		outer := g.Snapshot()
+		for i := range 3 {
+			g.AddEdge(Node[int]{1}, Node[int]{10 + i}, 1.0)
+			inner := g.Snapshot()
+			g.RemoveNode(Node[int]{1})
+			if err := g.Restore(inner); err != nil || !g.HasEdge(Node[int]{1}, Node[int]{10 + i}) {
+				t.Errorf("Expected inner restore to bring back the new edge, got %v", err)
+			}
+			if err := g.Restore(outer); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			// the inner snapshot was taken after outer, so it's gone
+			if err := g.Restore(inner); err == nil {
+				t.Errorf("Expected error restoring a snapshot that's gone")
+			}
+		}
+		sameGraph(t, &build().graphData, &g.graphData)
+	})
+
+	t.Run("Foreign and dropped snapshots", func(t *testing.T) {
+		g, h := build(), build()
+		s := g.Snapshot()
+		if err := h.Restore(s); err == nil {
+			t.Errorf("Expected error restoring a snapshot of another graph")
+		}
+		g.DropSnapshots()
+		g.AddNode(Node[int]{9})
+		if err := g.Restore(s); err == nil || !g.HasNode(Node[int]{9}) {
+			t.Errorf("Expected error restoring a dropped snapshot, got %v", err)
+		}
+		if c := g.Copy(); c.log != nil {
+			t.Errorf("Expected copies to start without snapshots")
+		}
+	})
+}
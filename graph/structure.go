@@ -0,0 +1,445 @@
+package graph
+
+import "math"
+
+// function to check whether a graph contains a cycle. this treats
+// edges as directed and walks the graph depth-first, keeping track
+// of the nodes on the current path. reaching a node that is still
+// on the path means we've gone around a loop. self-loops count
+func (g *graphData[K]) HasCycle() bool {
+	// nodes are either unvisited, on the current path, or done
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[Node[K]]int)
+
+	// a frame on the explicit stack remembers the node and how far
+	// we've gotten through its successors
+	type frame struct {
+		node       Node[K]
+		successors []Node[K]
+		next       int
+	}
+
+	// start a walk from every node that hasn't been seen yet
+	for root := range g.NodesSeq() {
+		if state[root] != unvisited {
+			continue
+		}
+		state[root] = onPath
+		stack := []frame{{node: root, successors: g.Successors(root)}}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			// all successors explored, the node is done
+			if top.next == len(top.successors) {
+				state[top.node] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			// look at the next successor
+			next := top.successors[top.next]
+			top.next++
+			switch state[next] {
+			case onPath:
+				// back on the current path, that's a cycle
+				return true
+			case unvisited:
+				state[next] = onPath
+				stack = append(stack, frame{node: next, successors: g.Successors(next)})
+			}
+		}
+	}
+	return false
+}
+
+// undirected graphs store each edge both ways, so walking back along
+// the edge we came from mustn't count as a cycle. any other edge to an
+// already visited node closes a loop. self-loops count
+func (g *UndirectedGraph[K]) HasCycle() bool {
+	visited := make(map[Node[K]]bool)
+
+	// each stack entry remembers the node it was reached from
+	type entry struct {
+		node, parent Node[K]
+	}
+
+	for root := range g.NodesSeq() {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		stack := []entry{{node: root, parent: root}}
+
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			for neighbor := range g.successorsSeq(current.node) {
+				// self-loop
+				if neighbor == current.node {
+					return true
+				}
+				// the edge we arrived on
+				if neighbor == current.parent {
+					continue
+				}
+				// reached another way already
+				if visited[neighbor] {
+					return true
+				}
+				visited[neighbor] = true
+				stack = append(stack, entry{node: neighbor, parent: current.node})
+			}
+		}
+	}
+	return false
+}
+
+// function to split an undirected graph into its connected components.
+// each component is returned as a list of its nodes. nodes without
+// any edges form a component of their own
+func (g *UndirectedGraph[K]) ConnectedComponents() [][]Node[K] {
+	components := make([][]Node[K], 0)
+	visited := make(map[Node[K]]bool)
+
+	// run a BFS from every node that isn't part of a component yet
+	for root := range g.NodesSeq() {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		queue := Queue[K]{root}
+		component := make([]Node[K], 0)
+
+		for len(queue) > 0 {
+			// pop the front of the queue, it's part of this component
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+
+			// queue up all neighbors we haven't seen yet
+			for neighbor := range g.successorsSeq(current) {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// function to return the number of connected components
+func (g *UndirectedGraph[K]) NumberOfComponents() int {
+	return len(g.ConnectedComponents())
+}
+
+// function to check whether an undirected graph is bipartite, i.e.
+// whether its nodes can be split into two groups so that every edge
+// runs between the groups. uses BFS two-coloring, restarting on each
+// component. returns the two groups if so, otherwise nil
+func (g *UndirectedGraph[K]) IsBipartite() (bool, [][]Node[K]) {
+	color := make(map[Node[K]]int)
+	classes := [][]Node[K]{make([]Node[K], 0), make([]Node[K], 0)}
+
+	for root := range g.NodesSeq() {
+		if _, ok := color[root]; ok {
+			continue
+		}
+		// start a new component with the first color
+		color[root] = 0
+		queue := Queue[K]{root}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			classes[color[current]] = append(classes[color[current]], current)
+
+			for neighbor := range g.successorsSeq(current) {
+				c, ok := color[neighbor]
+				if !ok {
+					// give neighbors the other color
+					color[neighbor] = 1 - color[current]
+					queue = append(queue, neighbor)
+				} else if c == color[current] {
+					// same color on both ends, this includes self-loops
+					return false, nil
+				}
+			}
+		}
+	}
+	return true, classes
+}
+
+// helper to list the neighbors of a node, ignoring self-loops
+func (g *UndirectedGraph[K]) loopFreeNeighbors(n Node[K]) []Node[K] {
+	neighbors := make([]Node[K], 0, g.OutDegree(n))
+	for neighbor := range g.successorsSeq(n) {
+		if neighbor != n {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// helper to count the neighbors of a node, ignoring self-loops, and
+// the number of edges running between those neighbors
+func (g *UndirectedGraph[K]) neighborLinks(n Node[K]) (int, int) {
+	neighbors := g.loopFreeNeighbors(n)
+	links := 0
+	for i := range neighbors {
+		for j := i + 1; j < len(neighbors); j++ {
+			if g.HasEdge(neighbors[i], neighbors[j]) {
+				links++
+			}
+		}
+	}
+	return links, len(neighbors)
+}
+
+// function to calculate the local clustering coefficient of a node:
+// the fraction of pairs of its neighbors that are connected themselves.
+// nodes with fewer than two neighbors have a coefficient of 0
+func (g *UndirectedGraph[K]) LocalClusteringCoefficient(n Node[K]) float64 {
+	links, degree := g.neighborLinks(n)
+	if degree < 2 {
+		return 0.0
+	}
+	return float64(links) / float64(degree*(degree-1)/2)
+}
+
+// function to calculate the global clustering coefficient: the fraction
+// of connected triples of nodes that are closed into a triangle. graphs
+// without any connected triples have a coefficient of 0
+func (g *UndirectedGraph[K]) GlobalClusteringCoefficient() float64 {
+	// every node is the center of a triple for each pair of neighbors
+	triples := 0
+	for n := range g.NodesSeq() {
+		degree := len(g.loopFreeNeighbors(n))
+		triples += degree * (degree - 1) / 2
+	}
+	if triples == 0 {
+		return 0.0
+	}
+	// every triangle closes three triples, one centered on each corner
+	return float64(3*g.CountTriangles()) / float64(triples)
+}
+
+// function to count the triangles in an undirected graph. every node
+// only looks at pairs of its neighbors with larger IDs than itself, so
+// each triangle is counted once, at its smallest corner. self-loops
+// are ignored
+func (g *UndirectedGraph[K]) CountTriangles() int {
+	triangles := 0
+	for n := range g.NodesSeq() {
+		larger := make([]Node[K], 0)
+		for _, neighbor := range g.loopFreeNeighbors(n) {
+			if compareIDs(neighbor.ID, n.ID) > 0 {
+				larger = append(larger, neighbor)
+			}
+		}
+		for i := range larger {
+			for j := i + 1; j < len(larger); j++ {
+				if g.HasEdge(larger[i], larger[j]) {
+					triangles++
+				}
+			}
+		}
+	}
+	return triangles
+}
+
+// function to check whether every edge has weight 1.0, so that counting
+// steps gives the same distances as adding up weights, see BFS. a graph
+// without edges is unweighted
+func (g *graphData[K]) IsUnweighted() bool {
+	for e := range g.EdgesSeq() {
+		if e.weight != 1.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// function to calculate the density of a directed graph: the number of
+// edges over the n(n-1) edges possible between n distinct nodes.
+// self-loops aren't counted. graphs with fewer than 2 nodes have a
+// density of 0
+func (g *graphData[K]) Density() float64 {
+	n := len(g.Adjacencies)
+	if n < 2 {
+		return 0.0
+	}
+	edges := 0
+	for u, neighbors := range g.Adjacencies {
+		edges += len(neighbors)
+		if _, ok := neighbors[u]; ok {
+			edges--
+		}
+	}
+	return float64(edges) / float64(n*(n-1))
+}
+
+// undirected graphs can have at most n(n-1)/2 edges between distinct
+// nodes. self-loops aren't counted
+func (g *UndirectedGraph[K]) Density() float64 {
+	n := len(g.Adjacencies)
+	if n < 2 {
+		return 0.0
+	}
+	edges := g.NumberOfEdges()
+	for u, neighbors := range g.Adjacencies {
+		if _, ok := neighbors[u]; ok {
+			edges--
+		}
+	}
+	return float64(edges) / float64(n*(n-1)/2)
+}
+
+// function to check whether an undirected graph is a forest, i.e. has
+// no cycles. it may be disconnected. the empty graph is a forest with
+// no trees in it
+func (g *UndirectedGraph[K]) IsForest() bool {
+	return !g.HasCycle()
+}
+
+// function to check whether an undirected graph is a tree, i.e. is
+// connected, has no cycles, and has exactly n-1 edges. the empty graph
+// isn't a tree
+func (g *UndirectedGraph[K]) IsTree() bool {
+	return len(g.Adjacencies) > 0 && g.IsForest() &&
+		g.NumberOfEdges() == len(g.Adjacencies)-1 &&
+		g.NumberOfComponents() == 1
+}
+
+// function to find the eccentricity of a node, the length of the
+// longest shortest path from it to any node it can reach, adding up
+// edge weights. nodes in other components don't count, so an isolated
+// node has eccentricity 0. a node not in the graph has +Inf
+func (g *UndirectedGraph[K]) Eccentricity(n Node[K]) float64 {
+	if !g.HasNode(n) {
+		return math.Inf(1)
+	}
+	distances, _ := g.Dijkstra(n)
+	eccentricity := 0.0
+	for _, d := range distances {
+		if !math.IsInf(d, 1) {
+			eccentricity = max(eccentricity, d)
+		}
+	}
+	return eccentricity
+}
+
+// helper to pick the nodes of each component whose eccentricity is the
+// best one in their component, the lowest or the highest if highest is
+// set. the nodes come back in ID order
+func (g *UndirectedGraph[K]) extremeEccentricity(highest bool) []Node[K] {
+	nodes := make([]Node[K], 0)
+	for _, component := range g.ConnectedComponents() {
+		eccentricities := make(map[Node[K]]float64, len(component))
+		best := 0.0
+		for i, n := range component {
+			e := g.Eccentricity(n)
+			eccentricities[n] = e
+			if i == 0 || (highest && e > best) || (!highest && e < best) {
+				best = e
+			}
+		}
+		// allow for rounding in sums of fractional weights
+		for _, n := range component {
+			if math.Abs(eccentricities[n]-best) < 1e-9 {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return sortedNodes(nodes)
+}
+
+// function to find the center of an undirected graph, the nodes whose
+// eccentricity equals the radius, the smallest eccentricity. these are
+// good roots, since no node is far from them. in a disconnected graph,
+// each component has its own radius, and the center of every component
+// is included. runs Dijkstra from every node
+func (g *UndirectedGraph[K]) Center() []Node[K] {
+	return g.extremeEccentricity(false)
+}
+
+// function to find the periphery of an undirected graph, the nodes whose
+// eccentricity equals the diameter, the largest eccentricity. in a
+// disconnected graph, each component has its own diameter, and the
+// periphery of every component is included, so isolated nodes are
+// always part of it. runs Dijkstra from every node
+func (g *UndirectedGraph[K]) Periphery() []Node[K] {
+	return g.extremeEccentricity(true)
+}
+
+// function to split a directed graph into its strongly connected
+// components, the groups of nodes that can all reach each other. uses
+// Kosaraju's algorithm: a first DFS records the order in which nodes
+// finish, then a second pass over the reversed graph, taking nodes in
+// reverse finishing order, picks up one component per walk. the
+// components come out in topological order of the condensation
+func (g *DirectedGraph[K]) StronglyConnectedComponents() [][]Node[K] {
+	// first pass, record the nodes in the order their DFS finishes
+	finished := make([]Node[K], 0, len(g.Adjacencies))
+	visited := make(map[Node[K]]bool)
+	type frame struct {
+		node       Node[K]
+		successors []Node[K]
+		next       int
+	}
+	for root := range g.NodesSeq() {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		stack := []frame{{node: root, successors: g.Successors(root)}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			// all successors explored, the node is finished
+			if top.next == len(top.successors) {
+				finished = append(finished, top.node)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			next := top.successors[top.next]
+			top.next++
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, frame{node: next, successors: g.Successors(next)})
+			}
+		}
+	}
+
+	// second pass, walk the reversed graph from the last finished node
+	// first. everything a walk reaches is one component
+	reversed := g.Reverse()
+	components := make([][]Node[K], 0)
+	assigned := make(map[Node[K]]bool)
+	for i := len(finished) - 1; i >= 0; i-- {
+		root := finished[i]
+		if assigned[root] {
+			continue
+		}
+		assigned[root] = true
+		component := make([]Node[K], 0)
+		stack := []Node[K]{root}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, current)
+			for neighbor := range reversed.successorsSeq(current) {
+				if !assigned[neighbor] {
+					assigned[neighbor] = true
+					stack = append(stack, neighbor)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
@@ -0,0 +1,440 @@
+package graph
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestHasCycle(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Undirected acyclic tree", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(w, x, 1.0)
+		if g.HasCycle() {
+			t.Errorf("Expected tree to have no cycle")
+		}
+	})
+
+	t.Run("Undirected triangle", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+		if !g.HasCycle() {
+			t.Errorf("Expected triangle to have a cycle")
+		}
+	})
+
+	t.Run("Undirected self loop", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, v, 1.0)
+		if !g.HasCycle() {
+			t.Errorf("Expected self loop to be a cycle")
+		}
+	})
+
+	t.Run("Directed acyclic tree", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(w, x, 1.0)
+		// two ways to reach x, but no way back
+		g.AddEdge(u, x, 1.0)
+		if g.HasCycle() {
+			t.Errorf("Expected DAG to have no cycle")
+		}
+	})
+
+	t.Run("Directed triangle", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+		if !g.HasCycle() {
+			t.Errorf("Expected triangle to have a cycle")
+		}
+	})
+
+	t.Run("Directed self loop", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, v, 1.0)
+		if !g.HasCycle() {
+			t.Errorf("Expected self loop to be a cycle")
+		}
+	})
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewUndirectedGraph[int]()
+	u, v, w, x, y, z := getNodes()
+
+	// two clusters and an isolated node
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 1.0)
+	g.AddEdge(x, y, 1.0)
+	g.AddNode(z)
+
+	t.Run("Number of components", func(t *testing.T) {
+		n := g.NumberOfComponents()
+		if n != 3 {
+			t.Errorf("Expected 3 components, got %d", n)
+		}
+	})
+
+	t.Run("Component membership", func(t *testing.T) {
+		// map each component size to how often it shows up
+		sizes := make(map[int]int)
+		for _, c := range g.ConnectedComponents() {
+			sizes[len(c)]++
+			// the isolated node must be on its own
+			if slices.Contains(c, z) && len(c) != 1 {
+				t.Errorf("Expected isolated node to form its own component, got %v", c)
+			}
+			// u and w are connected via v
+			if slices.Contains(c, u) && !slices.Contains(c, w) {
+				t.Errorf("Expected u and w in the same component, got %v", c)
+			}
+		}
+		if sizes[3] != 1 || sizes[2] != 1 || sizes[1] != 1 {
+			t.Errorf("Expected components of sizes 3, 2, and 1, got %v", sizes)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		h := NewUndirectedGraph[int]()
+		if n := h.NumberOfComponents(); n != 0 {
+			t.Errorf("Expected 0 components, got %d", n)
+		}
+	})
+}
+
+func TestIsBipartite(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	t.Run("Even cycle", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, x, 1.0)
+		g.AddEdge(x, u, 1.0)
+
+		ok, classes := g.IsBipartite()
+		if !ok {
+			t.Fatalf("Expected even cycle to be bipartite")
+		}
+		// opposite corners share a class
+		for _, c := range classes {
+			if len(c) != 2 {
+				t.Errorf("Expected classes of 2 nodes, got %v", classes)
+			}
+			if slices.Contains(c, u) && !slices.Contains(c, w) {
+				t.Errorf("Expected u and w in the same class, got %v", classes)
+			}
+		}
+	})
+
+	t.Run("Odd cycle", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+
+		ok, classes := g.IsBipartite()
+		if ok || classes != nil {
+			t.Errorf("Expected odd cycle not to be bipartite, got %t and %v", ok, classes)
+		}
+	})
+
+	t.Run("Forest", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(x, y, 1.0)
+		g.AddNode(z)
+
+		ok, classes := g.IsBipartite()
+		if !ok {
+			t.Fatalf("Expected forest to be bipartite")
+		}
+		if len(classes[0])+len(classes[1]) != 6 {
+			t.Errorf("Expected all 6 nodes to be colored, got %v", classes)
+		}
+		// every edge runs between the classes
+		for _, e := range g.Edges() {
+			if slices.Contains(classes[0], e.u) == slices.Contains(classes[0], e.v) {
+				t.Errorf("Expected edge %v to run between the classes", e)
+			}
+		}
+	})
+}
+
+func TestClusteringCoefficient(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Triangle", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+
+		for _, n := range []Node[int]{u, v, w} {
+			if c := g.LocalClusteringCoefficient(n); c != 1.0 {
+				t.Errorf("Expected local coefficient 1.0 for %v, got %f", n, c)
+			}
+		}
+		if c := g.GlobalClusteringCoefficient(); c != 1.0 {
+			t.Errorf("Expected global coefficient 1.0, got %f", c)
+		}
+	})
+
+	t.Run("Star", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(u, w, 1.0)
+		g.AddEdge(u, x, 1.0)
+
+		for _, n := range []Node[int]{u, v, w, x} {
+			if c := g.LocalClusteringCoefficient(n); c != 0.0 {
+				t.Errorf("Expected local coefficient 0.0 for %v, got %f", n, c)
+			}
+		}
+		if c := g.GlobalClusteringCoefficient(); c != 0.0 {
+			t.Errorf("Expected global coefficient 0.0, got %f", c)
+		}
+	})
+
+	t.Run("Triangle with a tail", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+		g.AddEdge(u, x, 1.0)
+		// self loops don't count as neighbors
+		g.AddEdge(x, x, 1.0)
+
+		if c := g.LocalClusteringCoefficient(u); c != 1.0/3.0 {
+			t.Errorf("Expected local coefficient 1/3 for u, got %f", c)
+		}
+		// 3 closed triples out of 5
+		if c := g.GlobalClusteringCoefficient(); c != 0.6 {
+			t.Errorf("Expected global coefficient 0.6, got %f", c)
+		}
+	})
+}
+
+func TestDensity(t *testing.T) {
+	u, v, w, x, _, _ := getNodes()
+
+	t.Run("Complete undirected graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		nodes := []Node[int]{u, v, w, x}
+		for i := range nodes {
+			for j := i + 1; j < len(nodes); j++ {
+				g.AddEdge(nodes[i], nodes[j], 1.0)
+			}
+		}
+		// self-loops don't count
+		g.AddEdge(u, u, 1.0)
+		if d := g.Density(); d != 1.0 {
+			t.Errorf("Expected density 1.0, got %f", d)
+		}
+	})
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, u, 1.0)
+		g.AddEdge(v, w, 1.0)
+		// 3 of 6 possible edges
+		if d := g.Density(); d != 0.5 {
+			t.Errorf("Expected density 0.5, got %f", d)
+		}
+	})
+
+	t.Run("Fewer than 2 nodes", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, u, 1.0)
+		if d := g.Density(); d != 0.0 {
+			t.Errorf("Expected density 0.0, got %f", d)
+		}
+	})
+}
+
+func TestIsUnweighted(t *testing.T) {
+	u, v, w, _, _, _ := getNodes()
+
+	t.Run("Empty graph", func(t *testing.T) {
+		if !NewDirectedGraph[int]().IsUnweighted() {
+			t.Errorf("Expected empty graph to be unweighted")
+		}
+	})
+
+	t.Run("Unit weights", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		if !g.IsUnweighted() {
+			t.Errorf("Expected graph with unit weights to be unweighted")
+		}
+		g.SetEdgeWeight(w, v, 0.5)
+		if g.IsUnweighted() {
+			t.Errorf("Expected graph with weight 0.5 to be weighted")
+		}
+	})
+}
+
+func TestIsTree(t *testing.T) {
+	t.Run("Path graph", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4})
+		if !g.IsTree() || !g.IsForest() {
+			t.Errorf("Expected path to be a tree and a forest")
+		}
+	})
+
+	t.Run("Path graph with an isolated node", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4})
+		g.AddNode(Node[int]{5})
+		if g.IsTree() || !g.IsForest() {
+			t.Errorf("Expected a forest that isn't a tree")
+		}
+	})
+
+	t.Run("Triangle", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3})
+		if g.IsTree() || g.IsForest() {
+			t.Errorf("Expected triangle to be neither a tree nor a forest")
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		if g.IsTree() || !g.IsForest() {
+			t.Errorf("Expected empty graph to be a forest but not a tree")
+		}
+	})
+}
+
+func TestCenterAndPeriphery(t *testing.T) {
+	t.Run("Path with a middle node", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4, 5})
+		if e := g.Eccentricity(Node[int]{1}); e != 4.0 {
+			t.Errorf("Expected eccentricity 4, got %f", e)
+		}
+		if center := g.Center(); !slices.Equal(center, []Node[int]{{3}}) {
+			t.Errorf("Expected center [3], got %v", center)
+		}
+		if periphery := g.Periphery(); !slices.Equal(periphery, []Node[int]{{1}, {5}}) {
+			t.Errorf("Expected periphery [1 5], got %v", periphery)
+		}
+	})
+
+	t.Run("Path with two middle nodes", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3, 4})
+		if center := g.Center(); !slices.Equal(center, []Node[int]{{2}, {3}}) {
+			t.Errorf("Expected center [2 3], got %v", center)
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		// the heavy edge pulls the center towards it
+		g := PathGraph([]int{1, 2, 3, 4, 5})
+		g.SetEdgeWeight(Node[int]{4}, Node[int]{5}, 10.0)
+		if center := g.Center(); !slices.Equal(center, []Node[int]{{4}}) {
+			t.Errorf("Expected center [4], got %v", center)
+		}
+	})
+
+	t.Run("Per component", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		g.AddEdge(Node[int]{4}, Node[int]{5}, 1.0)
+		g.AddNode(Node[int]{6})
+		if center := g.Center(); !slices.Equal(center, []Node[int]{{2}, {4}, {5}, {6}}) {
+			t.Errorf("Expected center [2 4 5 6], got %v", center)
+		}
+		if periphery := g.Periphery(); !slices.Equal(periphery, []Node[int]{{1}, {3}, {4}, {5}, {6}}) {
+			t.Errorf("Expected periphery [1 3 4 5 6], got %v", periphery)
+		}
+		if e := g.Eccentricity(Node[int]{7}); !math.IsInf(e, 1) {
+			t.Errorf("Expected +Inf for a missing node, got %f", e)
+		}
+	})
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	u, v, w, x, y, z := getNodes()
+
+	// a cycle u -> v -> w -> u, feeding a cycle x <-> y, and a lone z
+	g := NewDirectedGraph[int]()
+	g.AddEdge(u, v, 1.0)
+	g.AddEdge(v, w, 1.0)
+	g.AddEdge(w, u, 1.0)
+	g.AddEdge(w, x, 1.0)
+	g.AddEdge(x, y, 1.0)
+	g.AddEdge(y, x, 1.0)
+	g.AddNode(z)
+
+	components := g.StronglyConnectedComponents()
+	if len(components) != 3 {
+		t.Fatalf("Expected 3 components, got %v", components)
+	}
+	for _, c := range components {
+		switch {
+		case slices.Contains(c, u):
+			if len(c) != 3 || !slices.Contains(c, v) || !slices.Contains(c, w) {
+				t.Errorf("Expected u, v, and w together, got %v", c)
+			}
+		case slices.Contains(c, x):
+			if len(c) != 2 || !slices.Contains(c, y) {
+				t.Errorf("Expected x and y together, got %v", c)
+			}
+		default:
+			if len(c) != 1 || c[0] != z {
+				t.Errorf("Expected z on its own, got %v", c)
+			}
+		}
+	}
+
+	// the cycle feeding x and y comes first
+	first := slices.IndexFunc(components, func(c []Node[int]) bool { return slices.Contains(c, u) })
+	second := slices.IndexFunc(components, func(c []Node[int]) bool { return slices.Contains(c, x) })
+	if first > second {
+		t.Errorf("Expected components in topological order, got %v", components)
+	}
+}
+
+func TestCountTriangles(t *testing.T) {
+	t.Run("Complete graph", func(t *testing.T) {
+		g := CompleteGraph([]int{1, 2, 3, 4})
+		if n := g.CountTriangles(); n != 4 {
+			t.Errorf("Expected 4 triangles, got %d", n)
+		}
+	})
+
+	t.Run("Triangles and loops", func(t *testing.T) {
+		// two triangles sharing an edge, with a self-loop and a tail
+		g := NewUndirectedGraph[string]()
+		a, b, c, d, e := Node[string]{"a"}, Node[string]{"b"}, Node[string]{"c"}, Node[string]{"d"}, Node[string]{"e"}
+		g.AddEdge(a, b, 1.0)
+		g.AddEdge(b, c, 1.0)
+		g.AddEdge(c, a, 1.0)
+		g.AddEdge(b, d, 1.0)
+		g.AddEdge(c, d, 1.0)
+		g.AddEdge(d, e, 1.0)
+		g.AddEdge(a, a, 1.0)
+		if n := g.CountTriangles(); n != 2 {
+			t.Errorf("Expected 2 triangles, got %d", n)
+		}
+	})
+
+	t.Run("Trees have no triangles", func(t *testing.T) {
+		if n := StarGraph([]int{1, 2, 3, 4}).CountTriangles(); n != 0 {
+			t.Errorf("Expected no triangles, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// statistics about a graph, for a quick look at a parsed input. for
+// directed graphs, components are weakly connected ones, found by
+// ignoring the direction of the edges, and the degree of a node is the
+// sum of its in-degree and out-degree
+type Summary struct {
+	Directed   bool
+	Nodes      int
+	Edges      int
+	Density    float64
+	Components int
+	HasCycle   bool
+	SelfLoops  int
+	MinDegree  int
+	MaxDegree  int
+	MeanDegree float64
+}
+
+// summaries print on a single line, in words fitting the kind of graph
+func (s Summary) String() string {
+	var sb strings.Builder
+	kind, connected, cycles := "undirected", "connected", "acyclic"
+	if s.Directed {
+		kind, connected = "directed", "weakly connected"
+	}
+	if s.HasCycle {
+		cycles = "has cycles"
+	}
+	fmt.Fprintf(&sb, "%s graph: %s, %s, density %.3f, ", kind, plural(s.Nodes, "node"), plural(s.Edges, "edge"), s.Density)
+	fmt.Fprintf(&sb, "%s, %s, ", plural(s.Components, connected+" component"), cycles)
+	fmt.Fprintf(&sb, "%s, degree min %d max %d mean %.2f", plural(s.SelfLoops, "self-loop"), s.MinDegree, s.MaxDegree, s.MeanDegree)
+	return sb.String()
+}
+
+// helper to put a count in front of a noun, in plural where it needs one
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// helper to fill in the parts of a summary that work the same for both
+// kinds of graph. degree is the degree function matching the graph
+func (g *graphData[K]) summary(degree func(Node[K]) int) Summary {
+	s := Summary{
+		Nodes:     len(g.Adjacencies),
+		SelfLoops: len(g.SelfLoops()),
+	}
+	if s.Nodes == 0 {
+		return s
+	}
+
+	s.MinDegree = math.MaxInt
+	total := 0
+	for n := range g.NodesSeq() {
+		d := degree(n)
+		s.MinDegree = min(s.MinDegree, d)
+		s.MaxDegree = max(s.MaxDegree, d)
+		total += d
+	}
+	s.MeanDegree = float64(total) / float64(s.Nodes)
+	return s
+}
+
+// function to summarize a directed graph
+func (g *DirectedGraph[K]) Summary() Summary {
+	s := g.summary(g.Degree)
+	s.Directed = true
+	s.Edges = g.NumberOfEdges()
+	s.Density = g.Density()
+	s.HasCycle = g.HasCycle()
+
+	// ignoring directions, every edge joins two nodes into a component
+	uf := newUnionFind[K]()
+	s.Components = s.Nodes
+	for e := range g.EdgesSeq() {
+		if uf.union(e.u, e.v) {
+			s.Components--
+		}
+	}
+	return s
+}
+
+// function to summarize an undirected graph
+func (g *UndirectedGraph[K]) Summary() Summary {
+	s := g.summary(g.Degree)
+	s.Edges = g.NumberOfEdges()
+	s.Density = g.Density()
+	s.HasCycle = g.HasCycle()
+	s.Components = g.NumberOfComponents()
+	return s
+}
+
+// graphs print as their summary
+func (g *DirectedGraph[K]) String() string {
+	return g.Summary().String()
+}
+
+func (g *UndirectedGraph[K]) String() string {
+	return g.Summary().String()
+}
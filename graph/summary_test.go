@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	u, v, w, x, y, _ := getNodes()
+
+	t.Run("Directed graph", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(u, v, 1.0)
+		g.AddEdge(v, w, 1.0)
+		g.AddEdge(w, u, 1.0)
+		g.AddEdge(x, x, 1.0)
+
+		expected := Summary{
+			Directed: true, Nodes: 4, Edges: 4, Density: 0.25, Components: 2,
+			HasCycle: true, SelfLoops: 1, MinDegree: 2, MaxDegree: 2, MeanDegree: 2.0,
+		}
+		if s := g.Summary(); s != expected {
+			t.Errorf("Expected %+v, got %+v", expected, s)
+		}
+		text := "directed graph: 4 nodes, 4 edges, density 0.250, 2 weakly connected components, has cycles, 1 self-loop, degree min 2 max 2 mean 2.00"
+		if s := fmt.Sprint(g); s != text {
+			t.Errorf("Expected %q, got %q", text, s)
+		}
+	})
+
+	t.Run("Undirected graph", func(t *testing.T) {
+		g := StarGraph([]int{1, 2, 3, 4})
+		g.AddNode(y)
+
+		expected := Summary{
+			Nodes: 5, Edges: 3, Density: 0.3, Components: 2,
+			MinDegree: 0, MaxDegree: 3, MeanDegree: 1.2,
+		}
+		if s := g.Summary(); s != expected {
+			t.Errorf("Expected %+v, got %+v", expected, s)
+		}
+		text := "undirected graph: 5 nodes, 3 edges, density 0.300, 2 connected components, acyclic, 0 self-loops, degree min 0 max 3 mean 1.20"
+		if s := g.String(); s != text {
+			t.Errorf("Expected %q, got %q", text, s)
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		if s := g.Summary(); s != (Summary{}) {
+			t.Errorf("Expected an empty summary, got %+v", s)
+		}
+		text := "undirected graph: 0 nodes, 0 edges, density 0.000, 0 connected components, acyclic, 0 self-loops, degree min 0 max 0 mean 0.00"
+		if s := g.String(); s != text {
+			t.Errorf("Expected %q, got %q", text, s)
+		}
+	})
+}
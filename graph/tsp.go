@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// the most nodes the exact solvers take on. their tables have n * 2^n
+// entries, which is already over a hundred megabytes at this size
+const maxHeldKarpNodes = 20
+
+// helper to turn the nodes of a graph into a weight matrix for the
+// Held-Karp solvers. nodes are numbered in ID order, missing edges and
+// self-loops weigh +Inf
+func (g *graphData[K]) weightMatrix() ([]Node[K], [][]float64) {
+	nodes := sortedNodes(g.Nodes())
+	index := make(map[K]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID] = i
+	}
+	weights := make([][]float64, len(nodes))
+	for i, u := range nodes {
+		weights[i] = make([]float64, len(nodes))
+		for j := range weights[i] {
+			weights[i][j] = math.Inf(1)
+		}
+		for v, w := range g.Adjacencies[u.ID] {
+			if j := index[v]; j != i {
+				weights[i][j] = w
+			}
+		}
+	}
+	return nodes, weights
+}
+
+// helper to pick the way the Held-Karp solvers compare walks. returns the
+// cost of walks that can't be made, and whether one cost beats another
+func heldKarpGoal(longest bool) (float64, func(a, b float64) bool) {
+	if longest {
+		return math.Inf(-1), func(a, b float64) bool { return a > b }
+	}
+	return math.Inf(1), func(a, b float64) bool { return a < b }
+}
+
+// helper to run the Held-Karp dynamic program. the result holds the
+// cheapest walk, or the most expensive one if longest is set, that
+// starts at one of the given nodes, visits exactly the nodes in a
+// bitmask once each, and ends at node i, at index mask*n + i. walks
+// that can't be made cost +Inf, or -Inf when looking for the longest
+func heldKarp(weights [][]float64, starts []int, longest bool) []float64 {
+	n := len(weights)
+	none, better := heldKarpGoal(longest)
+	cost := make([]float64, n<<n)
+	for i := range cost {
+		cost[i] = none
+	}
+	for _, s := range starts {
+		cost[(1<<s)*n+s] = 0
+	}
+
+	// masks only ever grow, so going through them in order means every
+	// walk is final by the time it gets extended
+	for mask := 1; mask < 1<<n; mask++ {
+		for i := range n {
+			c := cost[mask*n+i]
+			if c == none {
+				continue
+			}
+			for j := range n {
+				if mask&(1<<j) != 0 || math.IsInf(weights[i][j], 1) {
+					continue
+				}
+				next := (mask|1<<j)*n + j
+				if better(c+weights[i][j], cost[next]) {
+					cost[next] = c + weights[i][j]
+				}
+			}
+		}
+	}
+	return cost
+}
+
+// helper to walk back through a Held-Karp table from the walk over mask
+// that ends at node end. returns the node numbers in walk order
+func heldKarpWalk(weights [][]float64, cost []float64, mask, end int) []int {
+	n := len(weights)
+	walk := make([]int, 0, n)
+	for {
+		walk = append(walk, end)
+		prev := mask &^ (1 << end)
+		if prev == 0 {
+			break
+		}
+		// find a node the walk could have come from. the costs were
+		// summed up the same way, so the one that was used matches exactly
+		for j := range n {
+			if prev&(1<<j) != 0 && cost[prev*n+j]+weights[j][end] == cost[mask*n+end] {
+				mask, end = prev, j
+				break
+			}
+		}
+	}
+	// the walk was collected from the end, turn it around
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk
+}
+
+// function to solve the traveling salesman problem exactly, finding the
+// cheapest cycle that leaves from start, visits every node of the graph
+// once, and comes back to start. uses the Held-Karp dynamic program,
+// which takes O(n^2 * 2^n) time, so it errors for graphs of more than
+// 20 nodes. the graph doesn't need to be complete, but it errors when
+// there is no such cycle at all. the path begins and ends with start
+func (g *UndirectedGraph[K]) TSPHeldKarp(start Node[K]) (Path[K], float64, error) {
+	if !g.HasNode(start) {
+		return nil, 0, fmt.Errorf("start node %v not in graph", start.ID)
+	}
+	n := len(g.Adjacencies)
+	if n > maxHeldKarpNodes {
+		return nil, 0, fmt.Errorf("graph has %d nodes, at most %d are supported", n, maxHeldKarpNodes)
+	}
+	if n == 1 {
+		return Path[K]{start, start}, 0, nil
+	}
+
+	nodes, weights := g.weightMatrix()
+	s := 0
+	for nodes[s] != start {
+		s++
+	}
+	cost := heldKarp(weights, []int{s}, false)
+
+	// close the cycle from whichever node is cheapest to come back from
+	full := 1<<n - 1
+	best, end := math.Inf(1), -1
+	for i := range n {
+		if i == s {
+			continue
+		}
+		if c := cost[full*n+i] + weights[i][s]; c < best {
+			best, end = c, i
+		}
+	}
+	if end < 0 {
+		return nil, 0, errors.New("graph has no cycle through every node")
+	}
+
+	path := make(Path[K], 0, n+1)
+	for _, i := range heldKarpWalk(weights, cost, full, end) {
+		path = append(path, nodes[i])
+	}
+	return append(path, start), best, nil
+}
+
+// function to approximate the traveling salesman problem for graphs too
+// big for TSPHeldKarp. starting at start, always moves on to the closest
+// node not yet visited, with ties going to the lowest ID, and goes back
+// to start at the end. this is fast but can be far from the best tour,
+// and errors if it gets stuck where the graph isn't complete, even when
+// another tour exists. the path begins and ends with start
+func (g *UndirectedGraph[K]) TSPNearestNeighbor(start Node[K]) (Path[K], float64, error) {
+	if !g.HasNode(start) {
+		return nil, 0, fmt.Errorf("start node %v not in graph", start.ID)
+	}
+	path := Path[K]{start}
+	if len(g.Adjacencies) == 1 {
+		return append(path, start), 0, nil
+	}
+
+	visited := map[Node[K]]bool{start: true}
+	total := 0.0
+	current := start
+	for len(visited) < len(g.Adjacencies) {
+		next, best, found := current, math.Inf(1), false
+		for _, v := range sortedNodes(g.Successors(current)) {
+			if w := g.Adjacencies[current.ID][v.ID]; !visited[v] && w < best {
+				next, best, found = v, w, true
+			}
+		}
+		if !found {
+			return nil, 0, fmt.Errorf("stuck at node %v with nodes left to visit", current.ID)
+		}
+		visited[next] = true
+		path = append(path, next)
+		total += best
+		current = next
+	}
+
+	w, ok := g.EdgeWeight(current, start)
+	if !ok {
+		return nil, 0, fmt.Errorf("no edge from last node %v back to start", current.ID)
+	}
+	return append(path, start), total + w, nil
+}
+
+// helper to find the cheapest path through every node of the graph once,
+// or the most expensive one if longest is set, starting at any of the
+// given nodes. shared by the Hamiltonian path functions
+func (g *graphData[K]) hamiltonianPath(starts []Node[K], longest bool) (Path[K], float64, error) {
+	for _, s := range starts {
+		if !g.HasNode(s) {
+			return nil, 0, fmt.Errorf("start node %v not in graph", s.ID)
+		}
+	}
+	n := len(g.Adjacencies)
+	if n > maxHeldKarpNodes {
+		return nil, 0, fmt.Errorf("graph has %d nodes, at most %d are supported", n, maxHeldKarpNodes)
+	}
+	if n == 0 {
+		return Path[K]{}, 0, nil
+	}
+
+	nodes, weights := g.weightMatrix()
+	index := make(map[Node[K]]int, n)
+	for i, node := range nodes {
+		index[node] = i
+	}
+	numbers := make([]int, len(starts))
+	for i, s := range starts {
+		numbers[i] = index[s]
+	}
+	cost := heldKarp(weights, numbers, longest)
+
+	// the path can end anywhere, take the best
+	full := 1<<n - 1
+	none, better := heldKarpGoal(longest)
+	best, end := none, -1
+	for i := range n {
+		if c := cost[full*n+i]; better(c, best) {
+			best, end = c, i
+		}
+	}
+	if end < 0 {
+		return nil, 0, errors.New("graph has no path through every node")
+	}
+
+	path := make(Path[K], 0, n)
+	for _, i := range heldKarpWalk(weights, cost, full, end) {
+		path = append(path, nodes[i])
+	}
+	return path, best, nil
+}
+
+// function to find the cheapest path that visits every node of the
+// graph exactly once, starting and ending wherever is cheapest. this is
+// the traveling salesman problem without the way back, solved exactly
+// the same way as TSPHeldKarp, so it errors for graphs of more than 20
+// nodes, and when no such path exists. an empty graph has an empty path
+func (g *graphData[K]) ShortestHamiltonianPath() (Path[K], float64, error) {
+	return g.hamiltonianPath(g.Nodes(), false)
+}
+
+// like ShortestHamiltonianPath, but the path has to start at start
+func (g *graphData[K]) ShortestHamiltonianPathFrom(start Node[K]) (Path[K], float64, error) {
+	return g.hamiltonianPath([]Node[K]{start}, false)
+}
+
+// function to find the most expensive path that visits every node of
+// the graph exactly once, starting and ending wherever gives the highest
+// total. works like ShortestHamiltonianPath, with the same limit of 20
+// nodes, and errors when no such path exists
+func (g *graphData[K]) LongestHamiltonianPath() (Path[K], float64, error) {
+	return g.hamiltonianPath(g.Nodes(), true)
+}
+
+// like LongestHamiltonianPath, but the path has to start at start
+func (g *graphData[K]) LongestHamiltonianPathFrom(start Node[K]) (Path[K], float64, error) {
+	return g.hamiltonianPath([]Node[K]{start}, true)
+}
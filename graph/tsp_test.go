@@ -0,0 +1,269 @@
+package graph
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// helper to find the cheapest tour from start by trying every order of
+// the other nodes
+func bruteForceTour(g *UndirectedGraph[int], start Node[int]) float64 {
+	best := math.Inf(1)
+	visited := map[Node[int]]bool{start: true}
+	var visit func(current Node[int], cost float64)
+	visit = func(current Node[int], cost float64) {
+		if len(visited) == g.NumberOfNodes() {
+			if w, ok := g.EdgeWeight(current, start); ok {
+				best = min(best, cost+w)
+			}
+			return
+		}
+		for _, v := range g.Successors(current) {
+			if !visited[v] {
+				visited[v] = true
+				visit(v, cost+g.Adjacencies[current.ID][v.ID])
+				delete(visited, v)
+			}
+		}
+	}
+	visit(start, 0)
+	return best
+}
+
+// helper to check that a path is a tour of the whole graph from start
+// with the given cost
+func checkTour(t *testing.T, g *UndirectedGraph[int], start Node[int], path Path[int], cost float64) {
+	t.Helper()
+	if len(path) != g.NumberOfNodes()+1 || path[0] != start || path[len(path)-1] != start {
+		t.Errorf("Expected a tour from %v through all nodes, got %v", start, path)
+		return
+	}
+	seen := make(map[Node[int]]bool)
+	for _, n := range path[1:] {
+		seen[n] = true
+	}
+	if len(seen) != g.NumberOfNodes() {
+		t.Errorf("Expected every node once, got %v", path)
+	}
+	if c, err := path.Cost(g); err != nil || math.Abs(c-cost) > 1e-9 {
+		t.Errorf("Expected path cost %f, got %f", cost, c)
+	}
+}
+
+func TestTSPHeldKarp(t *testing.T) {
+	t.Run("Square with diagonals", func(t *testing.T) {
+		g := CycleGraph([]int{1, 2, 3, 4})
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 5.0)
+		g.AddEdge(Node[int]{2}, Node[int]{4}, 5.0)
+		path, cost, err := g.TSPHeldKarp(Node[int]{3})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if cost != 4.0 {
+			t.Errorf("Expected cost 4, got %f", cost)
+		}
+		checkTour(t, g, Node[int]{3}, path, cost)
+	})
+
+	t.Run("Matches brute force", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(3, 4))
+		for range 20 {
+			g := NewUndirectedGraph[int]()
+			for u := range 7 {
+				g.AddNode(Node[int]{u})
+				for v := range u {
+					if rng.Float64() < 0.7 {
+						g.AddEdge(Node[int]{u}, Node[int]{v}, float64(rng.IntN(20)+1))
+					}
+				}
+			}
+			expected := bruteForceTour(g, Node[int]{0})
+			path, cost, err := g.TSPHeldKarp(Node[int]{0})
+			if math.IsInf(expected, 1) {
+				if err == nil {
+					t.Errorf("Expected error without a tour, got %v", path)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cost != expected {
+				t.Errorf("Expected cost %f, got %f", expected, cost)
+			}
+			checkTour(t, g, Node[int]{0}, path, cost)
+		}
+	})
+
+	t.Run("Single node", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddNode(Node[int]{1})
+		// the tour still begins and ends with the start
+		expected := Path[int]{{1}, {1}}
+		path, cost, err := g.TSPHeldKarp(Node[int]{1})
+		if err != nil || !slices.Equal(path, expected) || cost != 0 {
+			t.Errorf("Expected path %v, got %v, %f, %v", expected, path, cost, err)
+		}
+		path, cost, err = g.TSPNearestNeighbor(Node[int]{1})
+		if err != nil || !slices.Equal(path, expected) || cost != 0 {
+			t.Errorf("Expected path %v, got %v, %f, %v", expected, path, cost, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		g := PathGraph([]int{1, 2, 3})
+		if _, _, err := g.TSPHeldKarp(Node[int]{1}); err == nil {
+			t.Errorf("Expected error for graph without a tour")
+		}
+		if _, _, err := g.TSPHeldKarp(Node[int]{4}); err == nil {
+			t.Errorf("Expected error for missing start node")
+		}
+		ids := make([]int, 21)
+		for i := range ids {
+			ids[i] = i
+		}
+		if _, _, err := CycleGraph(ids).TSPHeldKarp(Node[int]{0}); err == nil {
+			t.Errorf("Expected error for graph that's too big")
+		}
+	})
+}
+
+func TestTSPNearestNeighbor(t *testing.T) {
+	t.Run("Greedy tour", func(t *testing.T) {
+		// the cheap first step leads into the expensive way back
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{1}, Node[int]{3}, 2.0)
+		g.AddEdge(Node[int]{1}, Node[int]{4}, 2.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{4}, 2.0)
+		g.AddEdge(Node[int]{3}, Node[int]{4}, 10.0)
+		path, cost, err := g.TSPNearestNeighbor(Node[int]{1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Path[int]{{1}, {2}, {3}, {4}, {1}}
+		if path.String() != expected.String() || cost != 14.0 {
+			t.Errorf("Expected %v with cost 14, got %v with cost %f", expected, path, cost)
+		}
+		if _, best, _ := g.TSPHeldKarp(Node[int]{1}); best >= cost {
+			t.Errorf("Expected the exact tour to be cheaper than %f, got %f", cost, best)
+		}
+	})
+
+	t.Run("Stuck", func(t *testing.T) {
+		g := StarGraph([]int{1, 2, 3})
+		if _, _, err := g.TSPNearestNeighbor(Node[int]{1}); err == nil {
+			t.Errorf("Expected error for graph without a tour")
+		}
+		if _, _, err := g.TSPNearestNeighbor(Node[int]{4}); err == nil {
+			t.Errorf("Expected error for missing start node")
+		}
+	})
+}
+
+func TestShortestHamiltonianPath(t *testing.T) {
+	// the example from 2015 day 9
+	g := NewUndirectedGraph[string]()
+	london, dublin, belfast := Node[string]{"London"}, Node[string]{"Dublin"}, Node[string]{"Belfast"}
+	g.AddEdge(london, dublin, 464)
+	g.AddEdge(london, belfast, 518)
+	g.AddEdge(dublin, belfast, 141)
+
+	t.Run("Any start", func(t *testing.T) {
+		path, cost, err := g.ShortestHamiltonianPath()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// the path works either way, ties go to the smallest end node
+		expected := Path[string]{london, dublin, belfast}
+		if cost != 605 || path.String() != expected.String() {
+			t.Errorf("Expected %v with cost 605, got %v with cost %f", expected, path, cost)
+		}
+	})
+
+	t.Run("Fixed start", func(t *testing.T) {
+		path, cost, err := g.ShortestHamiltonianPathFrom(dublin)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Path[string]{dublin, belfast, london}
+		if cost != 659 || path.String() != expected.String() {
+			t.Errorf("Expected %v with cost 659, got %v with cost %f", expected, path, cost)
+		}
+		if _, _, err := g.ShortestHamiltonianPathFrom(Node[string]{"Paris"}); err == nil {
+			t.Errorf("Expected error for missing start node")
+		}
+	})
+
+	t.Run("Directed", func(t *testing.T) {
+		d := NewDirectedGraph[int]()
+		d.AddEdge(Node[int]{3}, Node[int]{1}, 1.0)
+		d.AddEdge(Node[int]{1}, Node[int]{2}, 5.0)
+		d.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		path, cost, err := d.ShortestHamiltonianPath()
+		expected := Path[int]{{2}, {3}, {1}}
+		if err != nil || cost != 2.0 || path.String() != expected.String() {
+			t.Errorf("Expected %v with cost 2, got %v with cost %f and %v", expected, path, cost, err)
+		}
+	})
+
+	t.Run("No path", func(t *testing.T) {
+		if _, _, err := StarGraph([]int{1, 2, 3, 4}).ShortestHamiltonianPath(); err == nil {
+			t.Errorf("Expected error for graph without a Hamiltonian path")
+		}
+	})
+
+	t.Run("Empty graph", func(t *testing.T) {
+		path, cost, err := NewUndirectedGraph[int]().ShortestHamiltonianPath()
+		if err != nil || path == nil || len(path) != 0 || cost != 0 {
+			t.Errorf("Expected empty path, got %v, %f, %v", path, cost, err)
+		}
+	})
+}
+
+func TestLongestHamiltonianPath(t *testing.T) {
+	// the example from 2015 day 9 again, where the longest route is 982
+	g := NewUndirectedGraph[string]()
+	london, dublin, belfast := Node[string]{"London"}, Node[string]{"Dublin"}, Node[string]{"Belfast"}
+	g.AddEdge(london, dublin, 464)
+	g.AddEdge(london, belfast, 518)
+	g.AddEdge(dublin, belfast, 141)
+
+	t.Run("Any start", func(t *testing.T) {
+		path, cost, err := g.LongestHamiltonianPath()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Path[string]{dublin, london, belfast}
+		if cost != 982 || path.String() != expected.String() {
+			t.Errorf("Expected %v with cost 982, got %v with cost %f", expected, path, cost)
+		}
+	})
+
+	t.Run("Fixed start", func(t *testing.T) {
+		path, cost, err := g.LongestHamiltonianPathFrom(belfast)
+		if err != nil || cost != 982 || path[0] != belfast {
+			t.Errorf("Expected path from Belfast with cost 982, got %v with cost %f and %v", path, cost, err)
+		}
+	})
+
+	t.Run("Negative weights", func(t *testing.T) {
+		// a path has to be found even when every total is below zero
+		h := PathGraph([]int{1, 2, 3})
+		h.SetEdgeWeight(Node[int]{1}, Node[int]{2}, -2.0)
+		h.SetEdgeWeight(Node[int]{2}, Node[int]{3}, -3.0)
+		_, cost, err := h.LongestHamiltonianPath()
+		if err != nil || cost != -5.0 {
+			t.Errorf("Expected cost -5, got %f and %v", cost, err)
+		}
+	})
+
+	t.Run("No path", func(t *testing.T) {
+		if _, _, err := StarGraph([]int{1, 2, 3, 4}).LongestHamiltonianPath(); err == nil {
+			t.Errorf("Expected error for graph without a Hamiltonian path")
+		}
+	})
+}
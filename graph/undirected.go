@@ -1,5 +1,7 @@
 package graph
 
+import "iter"
+
 // UndirectedGraph inherits from graphData
 type UndirectedGraph[K comparable] struct {
 	graphData[K]
@@ -13,19 +15,27 @@ func NewUndirectedGraph[K comparable]() *UndirectedGraph[K] {
 }
 
 // adding new edges to an undirected graphs adds
-// them both ways, from u to v and from v to u
+// them both ways, from u to v and from v to u. if self-
+// loops are disallowed, an edge from a node to itself is ignored
 func (g *UndirectedGraph[K]) AddEdge(u, v Node[K], w float64) {
+	if u == v && g.noSelfLoops {
+		return
+	}
 	// add nodes to graph if they don't exist yet
-	if _, ok := g.Adjacencies[u]; !ok {
+	if _, ok := g.Adjacencies[u.ID]; !ok {
 		g.AddNode(u)
 	}
-	if _, ok := g.Adjacencies[v]; !ok {
+	if _, ok := g.Adjacencies[v.ID]; !ok {
 		g.AddNode(v)
 	}
 
 	// add the edges and adjacencies both ways
-	g.Adjacencies[u][v] = w
-	g.Adjacencies[v][u] = w
+	old, existed := g.Adjacencies[u.ID][v.ID]
+	g.setEdge(u, v, w)
+	g.setEdge(v, u, w)
+	if !existed || old != w {
+		g.notifyAddEdge(u, v, w)
+	}
 }
 
 // add from an iter of edges
@@ -35,19 +45,120 @@ func (g *UndirectedGraph[K]) AddEdgesFrom(es []Edge[K]) {
 	}
 }
 
+// undirected edges are stored both ways, so make room for twice
+// as many adjacencies
+func (g *UndirectedGraph[K]) Reserve(nodes, edges int) {
+	g.graphData.Reserve(nodes, 2*edges)
+}
+
+// like AddEdgesFrom, but makes room for nodeHint nodes and all of the
+// edges first. see Reserve
+func (g *UndirectedGraph[K]) AddEdgesFromWithHint(es []Edge[K], nodeHint int) {
+	g.Reserve(nodeHint, len(es))
+	g.AddEdgesFrom(es)
+}
+
 // remove an edge from an undirected graph
-// this removes the edge both ways
+// this removes the edge both ways. like HasEdge, it finds the edge
+// even if only one direction is left in the adjacencies
 func (g *UndirectedGraph[K]) RemoveEdge(u, v Node[K]) {
-	delete(g.Adjacencies[u], v)
-	delete(g.Adjacencies[v], u)
+	w, ok := g.EdgeWeight(u, v)
+	if !ok {
+		return
+	}
+	g.deleteEdge(u, v)
+	g.deleteEdge(v, u)
+	g.notifyRemoveEdge(u, v, w)
 }
 
 // remove edges from an undirected graph using an iter as the source
 func (g *UndirectedGraph[K]) RemoveEdgesFrom(es []Edge[K]) {
 	for _, e := range es {
-		delete(g.Adjacencies[e.u], e.v)
-		delete(g.Adjacencies[e.v], e.u)
+		g.RemoveEdge(e.u, e.v)
+	}
+}
+
+// each undirected edge is stored both ways, but should only be
+// reported once. edges are oriented so that the node with the smaller
+// ID comes first, see compareIDs. self-loops are reported once as well
+func (g *UndirectedGraph[K]) Edges() []Edge[K] {
+	edges := make([]Edge[K], 0, g.NumberOfEdges())
+	for e := range g.EdgesSeq() {
+		if e.u != e.v && compareIDs(e.u.ID, e.v.ID) > 0 {
+			e.u, e.v = e.v, e.u
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// sorting works on the edges as Edges reports them, once each
+func (g *UndirectedGraph[K]) SortedEdges(less func(a, b K) bool) []Edge[K] {
+	return sortEdgesBy(g.Edges(), less)
+}
+
+// the iterator reports each edge once like Edges, but doesn't order the
+// nodes of an edge, so it doesn't have to compare IDs. an edge comes out
+// oriented from whichever end the iteration reaches first
+func (g *UndirectedGraph[K]) EdgesSeq() iter.Seq[Edge[K]] {
+	return func(yield func(Edge[K]) bool) {
+		// nodes whose edges have all been reported
+		done := make(map[K]bool)
+		for u := range g.NodesSeq() {
+			for v, w := range g.successorsSeq(u) {
+				// reported from the other end, unless it's only stored this way
+				if _, ok := g.Adjacencies[v.ID][u.ID]; ok && done[v.ID] {
+					continue
+				}
+				if !yield(Edge[K]{u: u, v: v, weight: w}) {
+					return
+				}
+			}
+			done[u.ID] = true
+		}
+	}
+}
+
+// and the number of edges counts each undirected edge once. an edge
+// stored both ways is seen from both of its ends, a self-loop or an edge
+// only stored one way just from one
+func (g *UndirectedGraph[K]) NumberOfEdges() int {
+	once, twice := 0, 0
+	for u, neighbors := range g.Adjacencies {
+		for v := range neighbors {
+			if _, ok := g.Adjacencies[v][u]; ok && u != v {
+				twice++
+			} else {
+				once++
+			}
+		}
+	}
+	return once + twice/2
+}
+
+// the edge between u and v is unordered, so check both directions.
+// that keeps the answer consistent even if only one direction
+// has been removed from the adjacencies
+func (g *UndirectedGraph[K]) HasEdge(u, v Node[K]) bool {
+	return g.graphData.HasEdge(u, v) || g.graphData.HasEdge(v, u)
+}
+
+// like HasEdge, look up the weight in either direction
+func (g *UndirectedGraph[K]) EdgeWeight(u, v Node[K]) (float64, bool) {
+	if w, ok := g.graphData.EdgeWeight(u, v); ok {
+		return w, true
+	}
+	return g.graphData.EdgeWeight(v, u)
+}
+
+// change the weight of the edge both ways
+func (g *UndirectedGraph[K]) SetEdgeWeight(u, v Node[K], w float64) bool {
+	if !g.HasEdge(u, v) {
+		return false
 	}
+	g.setEdge(u, v, w)
+	g.setEdge(v, u, w)
+	return true
 }
 
 // override Neighbors, Predecessors, and Degrees for UndirectedGraph
@@ -61,7 +172,68 @@ func (g *UndirectedGraph[K]) Predecessors(n Node[K]) []Node[K] {
 	return g.Successors(n)
 }
 
-// and Degrees is just the number of neighbors
+// the same goes for their weighted versions
+func (g *UndirectedGraph[K]) WeightedNeighbors(n Node[K]) []Adjancency[K] {
+	return g.WeightedSuccessors(n)
+}
+
+func (g *UndirectedGraph[K]) WeightedPredecessors(n Node[K]) []Adjancency[K] {
+	return g.WeightedSuccessors(n)
+}
+
+// and Degrees is just the number of neighbors. a self-loop makes the
+// node its own neighbor, so it adds one to the degree. the same goes
+// for InDegree and OutDegree
 func (g *UndirectedGraph[K]) Degree(n Node[K]) int {
 	return len(g.Neighbors(n))
 }
+
+// conventionally, both ends of an undirected self-loop touch the node,
+// so it adds two to the degree
+func (g *UndirectedGraph[K]) DegreeWithLoops(n Node[K]) int {
+	degree := g.Degree(n)
+	if g.graphData.HasEdge(n, n) {
+		degree++
+	}
+	return degree
+}
+
+// like MapWeights, but changes the weights of a copy of the graph and
+// returns that, leaving the graph itself alone
+func (g *UndirectedGraph[K]) MappedWeights(f func(float64) float64) *UndirectedGraph[K] {
+	h := &UndirectedGraph[K]{graphData: *g.Copy()}
+	h.MapWeights(f)
+	return h
+}
+
+// function to create a new graph containing only the given nodes and
+// the edges between them. nodes that aren't in the graph are skipped
+func (g *UndirectedGraph[K]) Subgraph(nodes []Node[K]) *UndirectedGraph[K] {
+	return &UndirectedGraph[K]{graphData: g.subgraph(nodes)}
+}
+
+// function to create a new graph containing only the nodes that keep
+// returns true for, and the edges between them. the graph itself is
+// left alone
+func (g *UndirectedGraph[K]) FilterNodes(keep func(Node[K]) bool) *UndirectedGraph[K] {
+	nodes := make([]Node[K], 0)
+	for n := range g.NodesSeq() {
+		if keep(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	return g.Subgraph(nodes)
+}
+
+// function to create a new graph containing all the nodes, but only the
+// edges that keep returns true for. each edge is checked once, with its
+// nodes in the order Edges reports them. the graph itself is left alone
+func (g *UndirectedGraph[K]) FilterEdges(keep func(u, v Node[K], w float64) bool) *UndirectedGraph[K] {
+	h := g.Subgraph(g.Nodes())
+	for _, e := range g.Edges() {
+		if !keep(e.u, e.v, e.weight) {
+			h.RemoveEdge(e.u, e.v)
+		}
+	}
+	return h
+}
@@ -0,0 +1,54 @@
+package graph
+
+// disjoint-set forest over nodes with path compression and union
+// by size. nodes are added lazily the first time they're looked up
+type unionFind[K comparable] struct {
+	parent map[Node[K]]Node[K]
+	size   map[Node[K]]int
+}
+
+// helper to create an empty union-find structure
+func newUnionFind[K comparable]() *unionFind[K] {
+	return &unionFind[K]{
+		parent: make(map[Node[K]]Node[K]),
+		size:   make(map[Node[K]]int),
+	}
+}
+
+// function to find the representative of the set a node belongs to
+func (uf *unionFind[K]) find(n Node[K]) Node[K] {
+	// unseen nodes are their own set
+	if _, ok := uf.parent[n]; !ok {
+		uf.parent[n] = n
+		uf.size[n] = 1
+		return n
+	}
+	// walk up to the root
+	root := n
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	// and point everything on the way directly at it
+	for n != root {
+		next := uf.parent[n]
+		uf.parent[n] = root
+		n = next
+	}
+	return root
+}
+
+// function to merge the sets of two nodes. returns false if
+// they were already in the same set
+func (uf *unionFind[K]) union(u, v Node[K]) bool {
+	ru, rv := uf.find(u), uf.find(v)
+	if ru == rv {
+		return false
+	}
+	// hang the smaller tree below the larger one
+	if uf.size[ru] < uf.size[rv] {
+		ru, rv = rv, ru
+	}
+	uf.parent[rv] = ru
+	uf.size[ru] += uf.size[rv]
+	return true
+}
@@ -0,0 +1,77 @@
+package graph
+
+import "fmt"
+
+// helper to check the invariants every graph keeps: each node has both
+// of its tables, every edge leads to a node in the graph, and the
+// predecessors are exactly the adjacencies the other way around. if
+// symmetric is set, every edge also has to be stored both ways with the
+// same weight. nodes are checked in ID order, so the same graph usually
+// reports the same problem
+func (g *graphData[K]) validate(symmetric bool) error {
+	nodes := sortedNodes(g.Nodes())
+	for _, u := range nodes {
+		if g.Adjacencies[u.ID] == nil {
+			return fmt.Errorf("node %v has no adjacency table", u.ID)
+		}
+		if g.predecessors[u.ID] == nil {
+			return fmt.Errorf("node %v has no predecessor table", u.ID)
+		}
+	}
+	for _, u := range nodes {
+		for _, v := range sortedNodes(g.Successors(u)) {
+			w := g.Adjacencies[u.ID][v.ID]
+			if !g.HasNode(v) {
+				return fmt.Errorf("edge from %v leads to %v, which is not in the graph", u.ID, v.ID)
+			}
+			if p, ok := g.predecessors[v.ID][u.ID]; !ok {
+				return fmt.Errorf("edge from %v to %v is missing from the predecessors of %v", u.ID, v.ID, v.ID)
+			} else if p != w {
+				return fmt.Errorf("edge from %v to %v weighs %v, but %v in the predecessors", u.ID, v.ID, w, p)
+			}
+			if !symmetric {
+				continue
+			}
+			if back, ok := g.Adjacencies[v.ID][u.ID]; !ok {
+				return fmt.Errorf("edge from %v to %v has no edge back", u.ID, v.ID)
+			} else if back != w {
+				return fmt.Errorf("edge from %v to %v weighs %v, but %v the other way", u.ID, v.ID, w, back)
+			}
+		}
+	}
+	// the predecessors can't hold anything the adjacencies don't
+	for _, v := range nodes {
+		for _, u := range sortedNodes(g.Predecessors(v)) {
+			if _, ok := g.Adjacencies[u.ID][v.ID]; !ok {
+				return fmt.Errorf("predecessors of %v hold %v, but there is no edge from %v", v.ID, u.ID, u.ID)
+			}
+		}
+	}
+	// and nothing is kept for nodes that aren't in the graph
+	for id := range g.predecessors {
+		if _, ok := g.Adjacencies[id]; !ok {
+			return fmt.Errorf("predecessors hold node %v, which is not in the graph", id)
+		}
+	}
+	for id := range g.attributes {
+		if _, ok := g.Adjacencies[id]; !ok {
+			return fmt.Errorf("attributes are set for node %v, which is not in the graph", id)
+		}
+	}
+	return nil
+}
+
+// function to check that a directed graph is consistent, which it
+// always is unless Adjacencies was written to directly. every edge has
+// to lead to a node in the graph, and the lookup of incoming edges has
+// to match. returns an error describing the first problem found
+func (g *DirectedGraph[K]) Validate() error {
+	return g.validate(false)
+}
+
+// function to check that an undirected graph is consistent, like the
+// directed version, and that every edge is stored both ways with the
+// same weight. returns an error describing the first problem found
+func (g *UndirectedGraph[K]) Validate() error {
+	return g.validate(true)
+}
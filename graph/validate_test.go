@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	// helper to check that an error was returned and mentions what's wrong
+	expectError := func(t *testing.T, err error, mention string) {
+		t.Helper()
+		if err == nil {
+			t.Errorf("Expected an error mentioning %q, got nil", mention)
+		} else if !strings.Contains(err.Error(), mention) {
+			t.Errorf("Expected an error mentioning %q, got %q", mention, err)
+		}
+	}
+
+	t.Run("Consistent graphs", func(t *testing.T) {
+		u := NewUndirectedGraph[int]()
+		u.AddEdge(Node[int]{1}, Node[int]{2}, 1.5)
+		u.AddEdge(Node[int]{2}, Node[int]{2}, 1)
+		u.AddNode(Node[int]{3})
+		u.SetNodeAttribute(Node[int]{3}, "color", "red")
+		u.RemoveNode(Node[int]{1})
+		if err := u.Validate(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+
+		d := NewDirectedGraph[int]()
+		d.AddEdge(Node[int]{1}, Node[int]{2}, 1.5)
+		d.AddEdge(Node[int]{2}, Node[int]{3}, 2)
+		if err := d.Validate(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if err := NewDirectedGraph[int]().Validate(); err != nil {
+			t.Errorf("Expected nil for an empty graph, got %v", err)
+		}
+	})
+
+	t.Run("Asymmetric weights", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1)
+		g.Adjacencies[1][2] = 5
+		g.predecessors[2][1] = 5
+		expectError(t, g.Validate(), "the other way")
+	})
+
+	t.Run("One way edge", func(t *testing.T) {
+		g := NewUndirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1)
+		g.deleteEdge(Node[int]{2}, Node[int]{1})
+		expectError(t, g.Validate(), "no edge back")
+
+		// the same graph is fine when directed
+		d := NewDirectedGraph[int]()
+		d.AddEdge(Node[int]{1}, Node[int]{2}, 1)
+		if err := d.Validate(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("Dangling edge", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddNode(Node[int]{1})
+		g.Adjacencies[1][7] = 1
+		expectError(t, g.Validate(), "7, which is not in the graph")
+	})
+
+	t.Run("Predecessors out of sync", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1)
+		g.Adjacencies[2][1] = 1
+		expectError(t, g.Validate(), "edge from 2 to 1 is missing from the predecessors of 1")
+
+		h := NewDirectedGraph[int]()
+		h.AddEdge(Node[int]{1}, Node[int]{2}, 1)
+		delete(h.Adjacencies[1], 2)
+		expectError(t, h.Validate(), "no edge from 1")
+	})
+
+	t.Run("Node written directly", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.Adjacencies[1] = map[int]float64{}
+		expectError(t, g.Validate(), "no predecessor table")
+
+		h := NewDirectedGraph[int]()
+		h.AddNode(Node[int]{1})
+		h.SetNodeAttribute(Node[int]{1}, "color", "red")
+		delete(h.Adjacencies, 1)
+		expectError(t, h.Validate(), "not in the graph")
+	})
+}
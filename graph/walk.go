@@ -0,0 +1,67 @@
+package graph
+
+import "math/rand/v2"
+
+// helper to take a random walk. at each step, picks one of the
+// successors of the current node, with odds in proportion to the edge
+// weights if weighted is set, or all equal otherwise
+func (g *graphData[K]) randomWalk(start Node[K], steps int, rng *rand.Rand, weighted bool) Path[K] {
+	if !g.HasNode(start) {
+		return Path[K]{}
+	}
+	walk := Path[K]{start}
+	current := start
+	for range steps {
+		// successors are sorted so a seeded rng gives the same walk
+		successors := sortedNodes(g.Successors(current))
+		if !weighted {
+			if len(successors) == 0 {
+				break
+			}
+			current = successors[rng.IntN(len(successors))]
+			walk = append(walk, current)
+			continue
+		}
+
+		// edges that weigh nothing or less can't be taken
+		total := 0.0
+		for _, v := range successors {
+			total += max(g.Adjacencies[current.ID][v.ID], 0)
+		}
+		if total == 0 {
+			break
+		}
+		pick := rng.Float64() * total
+		next := current
+		for _, v := range successors {
+			w := g.Adjacencies[current.ID][v.ID]
+			if w <= 0 {
+				continue
+			}
+			next = v
+			if pick < w {
+				break
+			}
+			pick -= w
+		}
+		current = next
+		walk = append(walk, current)
+	}
+	return walk
+}
+
+// function to take a random walk of up to the given number of steps from
+// start, moving to a successor picked uniformly at random each step. the
+// walk stops early at a node without successors. all the randomness
+// comes from rng, so a seeded source gives the same walk. the path
+// includes start, and is empty if start isn't in the graph
+func (g *graphData[K]) RandomWalk(start Node[K], steps int, rng *rand.Rand) Path[K] {
+	return g.randomWalk(start, steps, rng, false)
+}
+
+// like RandomWalk, but successors are picked with odds in proportion to
+// the weight of the edge to them. edges weighing zero or less are never
+// taken, and the walk stops early at a node with no other edges out
+func (g *graphData[K]) RandomWalkWeighted(start Node[K], steps int, rng *rand.Rand) Path[K] {
+	return g.randomWalk(start, steps, rng, true)
+}
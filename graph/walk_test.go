@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRandomWalk(t *testing.T) {
+	t.Run("Valid and reproducible", func(t *testing.T) {
+		g := GridGraph(4, 4)
+		start := Node[Coordinate]{Coordinate{0, 0}}
+		walk := g.RandomWalk(start, 50, rand.New(rand.NewPCG(1, 2)))
+		if len(walk) != 51 || walk[0] != start {
+			t.Errorf("Expected 51 nodes from %v, got %v", start, walk)
+		}
+		if !walk.IsValidPath(g) {
+			t.Errorf("Expected walk along edges, got %v", walk)
+		}
+		again := g.RandomWalk(start, 50, rand.New(rand.NewPCG(1, 2)))
+		if walk.String() != again.String() {
+			t.Errorf("Expected same walk from the same seed, got %v and %v", walk, again)
+		}
+	})
+
+	t.Run("Stops at dead ends", func(t *testing.T) {
+		g := NewDirectedGraph[int]()
+		g.AddEdge(Node[int]{1}, Node[int]{2}, 1.0)
+		g.AddEdge(Node[int]{2}, Node[int]{3}, 1.0)
+		walk := g.RandomWalk(Node[int]{1}, 10, rand.New(rand.NewPCG(1, 2)))
+		expected := Path[int]{{1}, {2}, {3}}
+		if walk.String() != expected.String() {
+			t.Errorf("Expected %v, got %v", expected, walk)
+		}
+	})
+
+	t.Run("Missing start", func(t *testing.T) {
+		g := PathGraph([]int{1, 2})
+		if walk := g.RandomWalk(Node[int]{3}, 10, rand.New(rand.NewPCG(1, 2))); walk == nil || len(walk) != 0 {
+			t.Errorf("Expected empty walk, got %v", walk)
+		}
+	})
+}
+
+func TestRandomWalkWeighted(t *testing.T) {
+	t.Run("Follows the weights", func(t *testing.T) {
+		// from the hub, 3 is three times as likely as 2, and 4 never
+		g := StarGraph([]int{1, 2, 3, 4})
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{3}, 3.0)
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{4}, 0.0)
+		counts := make(map[Node[int]]int)
+		rng := rand.New(rand.NewPCG(5, 6))
+		for range 4000 {
+			walk := g.RandomWalkWeighted(Node[int]{1}, 1, rng)
+			counts[walk[1]]++
+		}
+		if counts[Node[int]{4}] != 0 {
+			t.Errorf("Expected weightless edge to never be taken, got %d", counts[Node[int]{4}])
+		}
+		if ratio := float64(counts[Node[int]{3}]) / float64(counts[Node[int]{2}]); math.Abs(ratio-3.0) > 0.3 {
+			t.Errorf("Expected ratio around 3, got %f", ratio)
+		}
+	})
+
+	t.Run("Stops without usable edges", func(t *testing.T) {
+		g := PathGraph([]int{1, 2})
+		g.SetEdgeWeight(Node[int]{1}, Node[int]{2}, 0.0)
+		if walk := g.RandomWalkWeighted(Node[int]{1}, 5, rand.New(rand.NewPCG(1, 2))); len(walk) != 1 {
+			t.Errorf("Expected walk of just the start, got %v", walk)
+		}
+	})
+}
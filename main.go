@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zn0k/goaoc/graph"
+)
+
+// read in the maze grid and return an undirected graph as well as the start
+// and end tile on the grid
+func readLines(fname string, directions []graph.Direction) (*graph.UndirectedGraph[graph.Coordinate], graph.Node[graph.Coordinate], graph.Node[graph.Coordinate]) {
+	f, err := os.Open(fname)
+	if err != nil {
+		panic(fmt.Sprintf("unable to open %s for reading", fname))
+	}
+	defer f.Close()
+
+	// the start and end tiles are walkable too. record where they are
+	// while the graph is built
+	var start, target graph.Node[graph.Coordinate]
+	walkable := func(r rune) bool {
+		return r == '.' || r == 'S' || r == 'T'
+	}
+	visit := func(r rune, c graph.Coordinate) {
+		switch r {
+		case 'S':
+			start = graph.Node[graph.Coordinate]{ID: c}
+		case 'T':
+			target = graph.Node[graph.Coordinate]{ID: c}
+		}
+	}
+
+	// build the graph from the walkable tiles
+	g, err := graph.BuildGridGraphFromReader(f, directions, walkable, visit)
+	if err != nil {
+		panic(err)
+	}
+
+	return g, start, target
+}
+
+func main() {
+	// this grid is only walkable in cardinal directions
+	g, s, t := readLines("input.txt", graph.CardinalDirections)
+
+	// run a BFS
+	path, length := g.BFS(s, t)
+	fmt.Printf("path=%v, length=%d\n", path, length)
+
+	// run dijkstra
+	path, length, _ = g.DijkstraTo(s, t)
+	fmt.Printf("path=%v, length=%d\n", path, length)
+}